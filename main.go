@@ -2,7 +2,11 @@ package main
 
 import (
 	"log"
+	"os"
 	"search-courses-api/src/config/builder"
+	rabbitmq "search-courses-api/src/config/rabbitMQ"
+	"search-courses-api/src/errors"
+	"search-courses-api/src/services"
 
 	"go.uber.org/zap"
 )
@@ -12,23 +16,64 @@ func main() {
 	logger := app.GetLogger()
 	searchService := app.GetSearchService()
 
-	// Iniciar el consumo de mensajes de RabbitMQ
-	rabbitMQ := app.GetRabbitMQ()
-	rabbitMQ.ConsumeMessages(func(message string) {
-		// Procesar cada mensaje (ID de curso)
-		err := searchService.UpdateCourseInSolr(message)
+	// "migrate" aplica las migraciones de esquema pendientes y termina
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.GetSolrClient().WaitForConnection()
+		result, err := searchService.MigrateSchema()
 		if err != nil {
-			logger.Error("Error al actualizar el curso en Solr", zap.Error(err))
+			logger.Fatal("Error al migrar el esquema de Solr", zap.Error(err))
 		}
-	})
+		logger.Info("Esquema de Solr migrado",
+			zap.Int("from_version", result.FromVersion),
+			zap.Int("to_version", result.ToVersion),
+			zap.Bool("reindexed", result.Reindexed))
+		return
+	}
 
 	// Esperar a que la conexión con Solr esté lista
-	app.GetSolrClient().WaitForConnection()
+	solrClient := app.GetSolrClient()
+	solrClient.WaitForConnection()
 
-	// Cargar todos los cursos en Solr al iniciar la aplicación
-	err := searchService.LoadAllCoursesIntoSolr()
+	// Crear los campos que el servicio necesita y aplicar las migraciones
+	// pendientes antes de indexar, ya que un cambio de tipo puede requerir
+	// borrar los documentos existentes
+	migration, err := searchService.MigrateSchema()
 	if err != nil {
-		logger.Error("Error al cargar los cursos en Solr", zap.Error(err))
+		logger.Error("Error al migrar el esquema de Solr", zap.Error(err))
+	}
+
+	// Iniciar el consumo de mensajes de RabbitMQ recién con el esquema al día:
+	// un curso indexado entre la purga y el reemplazo de un campo volvería a
+	// registrar el tipo de docValues anterior, o lo borraría la purga
+	rabbitMQ := app.GetRabbitMQ()
+	rabbitMQ.ConsumeMessages(func(message string) error {
+		// Procesar cada mensaje (evento de curso o ID de curso legacy)
+		err := searchService.HandleCourseMessage([]byte(message))
+		if appErr, ok := err.(*errors.Error); ok && appErr.Code == errors.ErrInvalidCourseEvent.Code {
+			logger.Error("Mensaje de RabbitMQ rechazado sin reintentos: no es un evento de curso válido",
+				zap.String("body", message),
+				zap.Error(err))
+			return rabbitmq.Permanent(err)
+		}
+		if err != nil {
+			logger.Error("Error al actualizar el curso en Solr", zap.Error(err))
+		}
+		return err
+	}, func(message string) string {
+		// Los eventos del mismo curso se procesan en orden en el mismo worker y
+		// se agrupan, ya que todos se resuelven releyendo o borrando el curso
+		if event, err := services.DecodeCourseEvent([]byte(message)); err == nil {
+			return event.CourseID
+		}
+		return ""
+	})
+
+	// Cargar todos los cursos en Solr al iniciar la aplicación, salvo que la
+	// migración ya los haya reindexado
+	if migration == nil || !migration.Reindexed {
+		if err := searchService.LoadAllCoursesIntoSolr(); err != nil {
+			logger.Error("Error al cargar los cursos en Solr", zap.Error(err))
+		}
 	}
 
 	// Iniciar el servidor HTTP
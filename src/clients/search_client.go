@@ -2,12 +2,16 @@ package clients
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"search-courses-api/src/config/envs"
+	"search-courses-api/src/config/search"
+	"search-courses-api/src/errors"
 	"search-courses-api/src/models"
+	"search-courses-api/src/querybuilder"
 
 	"github.com/vanng822/go-solr/solr"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,11 +24,15 @@ type SolrClient struct {
 	mu         sync.RWMutex
 	connected  bool
 	connCond   *sync.Cond
+	config     *search.Config
+	baseURL    string
+	core       string
 }
 
-func NewSolrClient(logger *zap.Logger) *SolrClient {
+func NewSolrClient(logger *zap.Logger, config *search.Config) *SolrClient {
 	client := &SolrClient{
 		logger: logger,
+		config: config,
 	}
 	client.connCond = sync.NewCond(&client.mu)
 	go client.connectWithRetry()
@@ -49,6 +57,8 @@ func (s *SolrClient) connectWithRetry() {
 
 		s.mu.Lock()
 		s.connection = solrInterface
+		s.baseURL = solrBaseURL
+		s.core = solrCore
 		s.connected = true
 		s.mu.Unlock()
 
@@ -75,80 +85,86 @@ func (s *SolrClient) IsConnected() bool {
 	return s.connected
 }
 
-func (s *SolrClient) AddCourse(course *models.SearchCourseModel) error {
+// AddCourse indexa un curso. Como en AddCourses, una fecha de inicio no
+// reconocida no impide indexarlo y se informa en InvalidInitDates.
+func (s *SolrClient) AddCourse(course *models.SearchCourseModel) (*models.IndexReport, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	if !s.connected {
-		return fmt.Errorf("Conexión a Solr no establecida")
+		return nil, fmt.Errorf("Conexión a Solr no establecida")
 	}
 
 	s.logger.Info("Agregando curso a Solr",
 		zap.String("course_id", course.ID.Hex()),
 		zap.String("course_name", course.CourseName))
 
-	doc := solr.Document{
-		"id":            course.ID.Hex(),
-		"course_name":   course.CourseName,
-		"description":   course.CourseDescription,
-		"price":         course.CoursePrice,
-		"duration":      course.CourseDuration,
-		"init_date":     course.CourseInitDate,
-		"state":         course.CourseState,
-		"capacity":      course.CourseCapacity,
-		"image":         course.CourseImage,
-		"category_id":   course.CategoryID.Hex(),
-		"category_name": course.CategoryName,
-		"ratingavg":     course.RatingAvg,
+	report := &models.IndexReport{Total: 1, Batches: 1}
+	doc, dateErr := s.courseToDocument(course)
+	if dateErr != nil {
+		report.InvalidInitDates = append(report.InvalidInitDates, course.ID.Hex())
 	}
-
-	docs := []solr.Document{doc}
-
-	_, err := s.connection.Add(docs, 0, nil)
-	if err != nil {
+	if err := s.addDocuments([]solr.Document{doc}); err != nil {
 		s.logger.Error("Error al agregar curso a Solr",
 			zap.String("course_id", course.ID.Hex()),
 			zap.Error(err))
-		return err
+		return nil, err
 	}
+	report.Indexed = 1
 
 	s.logger.Info("Curso agregado exitosamente a Solr",
 		zap.String("course_id", course.ID.Hex()))
 
-	// Commit los cambios
-	_, err = s.connection.Commit()
-	if err != nil {
+	// Commit los cambios según la política configurada
+	if err := s.commit(); err != nil {
 		s.logger.Error("Error al hacer commit en Solr", zap.Error(err))
-		return err
+		return nil, err
 	}
 
-	return nil
+	return report, nil
 }
 
-func (s *SolrClient) SearchCourses(query string) ([]models.SearchCourseModel, error) {
+func (s *SolrClient) SearchCourses(params models.SearchCoursesQuery) (*models.SearchCoursesResult, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	if !s.connected {
 		return nil, fmt.Errorf("Conexión a Solr no establecida")
 	}
 
-	// Crear una nueva query de Solr
-	solrQuery := solr.NewQuery()
-
-	// Construir la query en base al parámetro recibido
-	if query != "" {
-		// Escapar caracteres especiales en la query
-		escapedQuery := strings.Replace(query, ":", "\\:", -1)
-		escapedQuery = strings.Replace(escapedQuery, " ", "\\ ", -1)
+	query := params.Query
+
+	// Construir la query de Solr a partir de los parámetros tipados
+	solrQuery, err := querybuilder.Query{
+		Text: query,
+		// Consulta de relevancia con edismax: los boosts por campo se configuran en qf/pf/mm
+		Edismax: querybuilder.Edismax{
+			QueryFields:  s.config.QueryFields,
+			PhraseFields: s.config.PhraseFields,
+			MinimumMatch: s.config.MinimumMatch,
+		},
+		Fuzzy:   s.fuzzyOptions(params.Fuzzy),
+		Filters: buildFilters(params.Filters),
+		Sort:    buildSort(params.Sort),
+		Start:   params.Start(),
+		Rows:    params.PageSize,
+
+		CursorMark: params.Cursor,
+	}.Build()
+	if err != nil {
+		s.logger.Error("[SEARCH-API] Error al construir la query de Solr",
+			zap.String("query", query),
+			zap.Error(err))
+		return nil, err
+	}
 
-		// Búsqueda en múltiples campos
-		solrQuery.Q(fmt.Sprintf("(course_name:*%s* OR description:*%s* OR category_name:*%s*)", escapedQuery, escapedQuery, escapedQuery))
-	} else {
-		// Si no se envía query, devolver todos los resultados
-		solrQuery.Q("*:*")
+	// Facetas para los filtros del catálogo
+	if params.IncludeFacets {
+		s.addFacetParams(solrQuery)
 	}
 
-	// Limitar el número de resultados
-	solrQuery.Rows(100)
+	// Resaltado de coincidencias
+	if params.Highlight {
+		s.addHighlightParams(solrQuery)
+	}
 
 	// Log de la consulta que se ejecutará en Solr
 	s.logger.Info("[SEARCH-API] Ejecutando búsqueda en Solr",
@@ -157,6 +173,18 @@ func (s *SolrClient) SearchCourses(query string) ([]models.SearchCourseModel, er
 	// Ejecutar la búsqueda
 	response := s.connection.Search(solrQuery)
 	res, err := response.Result(nil)
+	if err == nil {
+		err = solrResultError(res)
+	}
+	// Un cursorMark adulterado o de otro orden llega bien formado como token
+	// pero Solr lo rechaza: es un error del cliente, no del servicio
+	if solrErr, ok := err.(*solrError); ok && params.Cursor != "" &&
+		solrErr.code == http.StatusBadRequest && strings.Contains(solrErr.msg, "cursorMark") {
+		s.logger.Warn("[SEARCH-API] Solr rechazó el cursorMark",
+			zap.String("cursor", params.Cursor),
+			zap.Error(err))
+		return nil, errors.ErrInvalidCursor
+	}
 	if err != nil {
 		// Log de error en caso de fallo
 		s.logger.Error("[SEARCH-API] Error al ejecutar búsqueda en Solr",
@@ -165,52 +193,164 @@ func (s *SolrClient) SearchCourses(query string) ([]models.SearchCourseModel, er
 		return nil, err
 	}
 
+	result := &models.SearchCoursesResult{}
+	if res == nil || res.Results == nil {
+		return result, nil
+	}
+
 	// Log de la respuesta recibida
 	s.logger.Debug("[SEARCH-API] Respuesta recibida de Solr",
-		zap.Int("total_resultados", len(res.Results.Docs)))
+		zap.Int("total_resultados", len(res.Results.Docs)),
+		zap.Int("num_found", res.Results.NumFound))
 
 	// Procesar los documentos obtenidos y convertirlos en el modelo de la aplicación
-	var courses []models.SearchCourseModel
-	if res != nil && res.Results != nil {
-		for _, doc := range res.Results.Docs {
-			s.logger.Info("Procesando documento Solr", zap.Any("doc", doc))
-
-			course := models.SearchCourseModel{}
-
-			// Parsear ID del curso
-			if idStr, ok := doc["id"].(string); ok {
-				if oid, err := primitive.ObjectIDFromHex(idStr); err == nil {
-					course.ID = oid
-				}
-			}
-
-			// Asignar valores de los campos
-			course.CourseName = getStringValue(doc, "course_name")
-			course.CourseDescription = getStringValue(doc, "description")
-			course.CoursePrice = getFloat64Value(doc, "price")
-			course.CourseDuration = getIntValue(doc, "duration")
-			course.CourseInitDate = getStringValue(doc, "init_date")
-			course.CourseState = getBoolValue(doc, "state")
-			course.CourseCapacity = getIntValue(doc, "capacity")
-			course.CourseImage = getStringValue(doc, "image")
-
-			// Parsear categoría del curso
-			if categoryIDStr := getStringValue(doc, "category_id"); categoryIDStr != "" {
-				if categoryID, err := primitive.ObjectIDFromHex(categoryIDStr); err == nil {
-					course.CategoryID = categoryID
-				}
-			}
-
-			course.CategoryName = getStringValue(doc, "category_name")
-			course.RatingAvg = getFloat64Value(doc, "ratingavg")
-
-			// Agregar el curso a la lista
-			courses = append(courses, course)
+	result.Total = res.Results.NumFound
+	// Solr repite el mismo cursorMark cuando ya no quedan resultados
+	if params.Cursor != "" && res.NextCursorMark != params.Cursor {
+		result.NextCursor = res.NextCursorMark
+	}
+	if params.IncludeFacets {
+		result.Facets = s.parseFacets(res)
+	}
+	for _, doc := range res.Results.Docs {
+		s.logger.Info("Procesando documento Solr", zap.Any("doc", doc))
+
+		course := documentToCourse(doc)
+		if params.Highlight {
+			course.Highlights = parseHighlights(res, getStringValue(doc, "id"))
 		}
+
+		// Agregar el curso a la lista
+		result.Courses = append(result.Courses, course)
 	}
 
 	// Retornar los cursos encontrados
-	return courses, nil
+	return result, nil
+}
+
+// Largo mínimo de término con fuzzy activado explícitamente
+const fuzzyOnMinLength = 3
+
+// fuzzyOptions traduce el modo de búsqueda difusa a las opciones del query builder
+func (s *SolrClient) fuzzyOptions(mode string) *querybuilder.Fuzzy {
+	if mode == "" {
+		mode = s.config.FuzzyDefaultMode
+	}
+
+	minLength := 0
+	switch mode {
+	case models.FuzzyOn:
+		minLength = fuzzyOnMinLength
+	case models.FuzzyAuto:
+		// El modo auto solo expande términos más largos que el umbral configurado
+		minLength = s.config.FuzzyAutoMinLength + 1
+	default:
+		return nil
+	}
+
+	return &querybuilder.Fuzzy{
+		Fields:    s.config.FuzzyFields,
+		Boost:     s.config.FuzzyBoost,
+		MinLength: minLength,
+	}
+}
+
+// GetCourseByID busca un curso indexado por su id. Devuelve nil si no existe.
+func (s *SolrClient) GetCourseByID(courseID string) (*models.SearchCourseModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return nil, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	solrQuery, err := querybuilder.Query{
+		Filters: []querybuilder.Filter{
+			{Clause: querybuilder.Terms{Field: "id", Values: []string{courseID}}},
+		},
+		Rows: 1,
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("[SEARCH-API] Buscando curso por ID en Solr",
+		zap.String("course_id", courseID))
+
+	res, err := s.connection.Search(solrQuery).Result(nil)
+	if err == nil {
+		err = solrResultError(res)
+	}
+	if err != nil {
+		s.logger.Error("[SEARCH-API] Error al buscar curso por ID en Solr",
+			zap.String("course_id", courseID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	if res == nil || res.Results == nil || len(res.Results.Docs) == 0 {
+		return nil, nil
+	}
+
+	course := documentToCourse(res.Results.Docs[0])
+	return &course, nil
+}
+
+// solrError es una respuesta de error de Solr, con el código HTTP y el mensaje que informa
+type solrError struct {
+	code int
+	msg  string
+}
+
+func (e *solrError) Error() string {
+	return fmt.Sprintf("Solr respondió con error %d: %s", e.code, e.msg)
+}
+
+// solrResultError devuelve el error de una respuesta de búsqueda. go-solr no lo
+// devuelve como err: solo completa res.Error y deja la respuesta sin resultados.
+func solrResultError(res *solr.SolrResult) error {
+	if res == nil || (res.Error == nil && res.Status == 0) {
+		return nil
+	}
+	code, _ := res.Error["code"].(float64)
+	msg, _ := res.Error["msg"].(string)
+	if code == 0 {
+		code = float64(res.Status)
+	}
+	return &solrError{code: int(code), msg: msg}
+}
+
+// documentToCourse convierte un documento de Solr en el modelo de la aplicación
+func documentToCourse(doc solr.Document) models.SearchCourseModel {
+	course := models.SearchCourseModel{}
+
+	// Parsear ID del curso
+	if idStr, ok := doc["id"].(string); ok {
+		if oid, err := primitive.ObjectIDFromHex(idStr); err == nil {
+			course.ID = oid
+		}
+	}
+
+	// Asignar valores de los campos
+	course.CourseName = getStringValue(doc, "course_name")
+	course.CourseDescription = getStringValue(doc, "description")
+	course.CoursePrice = getFloat64Value(doc, "price")
+	course.CourseDuration = getIntValue(doc, "duration")
+	course.CourseInitDate = getStringValue(doc, "init_date")
+	course.CourseState = getBoolValue(doc, "state")
+	course.CourseCapacity = getIntValue(doc, "capacity")
+	course.CourseImage = getStringValue(doc, "image")
+
+	// Parsear categoría del curso
+	if categoryIDStr := getStringValue(doc, "category_id"); categoryIDStr != "" {
+		if categoryID, err := primitive.ObjectIDFromHex(categoryIDStr); err == nil {
+			course.CategoryID = categoryID
+		}
+	}
+
+	course.CategoryName = getStringValue(doc, "category_name")
+	course.RatingAvg = getFloat64Value(doc, "ratingavg")
+
+	return course
 }
 
 func getStringValue(doc map[string]interface{}, key string) string {
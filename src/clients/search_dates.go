@@ -0,0 +1,19 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseInitDate interpreta la fecha de inicio de courses-api con los formatos
+// configurados y la devuelve en UTC con el formato ISO-8601 de los campos pdate
+func (s *SolrClient) parseInitDate(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range s.config.InitDateFormats {
+		if date, err := time.Parse(strings.TrimSpace(layout), raw); err == nil {
+			return date.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("fecha de inicio %q no coincide con ningún formato aceptado", raw)
+}
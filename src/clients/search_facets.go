@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"fmt"
+	"strconv"
+
+	"search-courses-api/src/models"
+
+	"github.com/vanng822/go-solr/solr"
+)
+
+// Facetas de campo: nombre expuesto en la respuesta -> campo de Solr.
+// category_name es un campo de texto, por lo que se facetea su copia sin tokenizar.
+// Exclude es el tag del filtro que la faceta ignora al contar.
+var fieldFacets = []struct {
+	Name    string
+	Field   string
+	Exclude string
+}{
+	{Name: "category_id", Field: "category_id", Exclude: categoryFilterTag},
+	{Name: "category_name", Field: "category_name_str", Exclude: categoryFilterTag},
+	{Name: "state", Field: "state", Exclude: stateFilterTag},
+}
+
+type rangeFacet struct {
+	Bounds  []float64
+	Exclude string
+}
+
+// rangeFacets devuelve los campos con facetas por rango y sus límites configurados
+func (s *SolrClient) rangeFacets() map[string]rangeFacet {
+	return map[string]rangeFacet{
+		"price":     {Bounds: s.config.PriceBuckets, Exclude: priceFilterTag},
+		"duration":  {Bounds: s.config.DurationBuckets, Exclude: durationFilterTag},
+		"ratingavg": {Bounds: s.config.RatingBuckets, Exclude: ratingFilterTag},
+	}
+}
+
+// addFacetParams agrega a la query las facetas de campo y una facet.query por cada bucket de rango
+func (s *SolrClient) addFacetParams(solrQuery *solr.Query) {
+	solrQuery.AddParam("facet", "true")
+	solrQuery.SetFacetMinCount(1)
+	solrQuery.SetParam("facet.limit", strconv.Itoa(s.config.FacetLimit))
+
+	for _, facet := range fieldFacets {
+		solrQuery.AddFacet(fmt.Sprintf("{!key=%s ex=%s}%s", facet.Name, facet.Exclude, facet.Field))
+	}
+
+	for field, facet := range s.rangeFacets() {
+		for i, bucket := range rangeBuckets(facet.Bounds) {
+			solrQuery.AddFacetQuery(fmt.Sprintf("{!key=%s ex=%s}%s:[%s TO %s}",
+				rangeBucketKey(field, i), facet.Exclude, field, formatBound(bucket.From), formatBound(bucket.To)))
+		}
+	}
+}
+
+// parseFacets lee facet_fields y facet_queries de la respuesta de Solr
+func (s *SolrClient) parseFacets(res *solr.SolrResult) *models.SearchFacets {
+	facets := &models.SearchFacets{
+		Fields: map[string][]models.FacetBucket{},
+		Ranges: map[string][]models.RangeFacetBucket{},
+	}
+	if res.FacetCounts == nil {
+		return facets
+	}
+
+	if facetFields, ok := res.FacetCounts["facet_fields"].(map[string]interface{}); ok {
+		for _, facet := range fieldFacets {
+			// Solr devuelve cada faceta como una lista plana [valor, cantidad, valor, cantidad, ...]
+			values, _ := facetFields[facet.Name].([]interface{})
+			buckets := make([]models.FacetBucket, 0, len(values)/2)
+			for i := 0; i+1 < len(values); i += 2 {
+				count, _ := values[i+1].(float64)
+				buckets = append(buckets, models.FacetBucket{
+					Value: fmt.Sprint(values[i]),
+					Count: int(count),
+				})
+			}
+			facets.Fields[facet.Name] = buckets
+		}
+	}
+
+	facetQueries, _ := res.FacetCounts["facet_queries"].(map[string]interface{})
+	for field, facet := range s.rangeFacets() {
+		buckets := []models.RangeFacetBucket{}
+		for i, bucket := range rangeBuckets(facet.Bounds) {
+			count, _ := facetQueries[rangeBucketKey(field, i)].(float64)
+			if count == 0 {
+				continue
+			}
+			bucket.Count = int(count)
+			buckets = append(buckets, bucket)
+		}
+		facets.Ranges[field] = buckets
+	}
+
+	return facets
+}
+
+// rangeBuckets arma los intervalos definidos por los límites: (*, b0), [b0, b1), ..., [bn, *)
+func rangeBuckets(bounds []float64) []models.RangeFacetBucket {
+	buckets := make([]models.RangeFacetBucket, 0, len(bounds)+1)
+	var from *float64
+	for i := range bounds {
+		to := bounds[i]
+		buckets = append(buckets, models.RangeFacetBucket{From: from, To: &to})
+		from = &to
+	}
+	return append(buckets, models.RangeFacetBucket{From: from})
+}
+
+func rangeBucketKey(field string, index int) string {
+	return fmt.Sprintf("%s_%d", field, index)
+}
+
+func formatBound(bound *float64) string {
+	if bound == nil {
+		return "*"
+	}
+	return strconv.FormatFloat(*bound, 'f', -1, 64)
+}
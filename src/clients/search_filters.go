@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"time"
+
+	"search-courses-api/src/models"
+	"search-courses-api/src/querybuilder"
+)
+
+// Tags de los fq. Permiten que cada faceta excluya su propio filtro y siga
+// mostrando los valores alternativos (selección múltiple en el catálogo).
+const (
+	categoryFilterTag = "category"
+	priceFilterTag    = "price"
+	durationFilterTag = "duration"
+	ratingFilterTag   = "rating"
+	stateFilterTag    = "state"
+	initDateFilterTag = "init_date"
+	capacityFilterTag = "capacity"
+)
+
+var dateUnits = map[string]querybuilder.DateUnit{
+	models.WindowDays:   querybuilder.Days,
+	models.WindowMonths: querybuilder.Months,
+	models.WindowYears:  querybuilder.Years,
+}
+
+// buildFilters traduce los filtros estructurados a cláusulas fq tipadas
+func buildFilters(filters models.SearchFilters) []querybuilder.Filter {
+	fqs := []querybuilder.Filter{metadataExclusion}
+
+	if len(filters.CategoryIDs) > 0 {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    categoryFilterTag,
+			Clause: querybuilder.Terms{Field: "category_id", Values: filters.CategoryIDs},
+		})
+	}
+
+	if filters.PriceMin != nil || filters.PriceMax != nil {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    priceFilterTag,
+			Clause: querybuilder.Range{Field: "price", From: floatBound(filters.PriceMin), To: floatBound(filters.PriceMax)},
+		})
+	}
+
+	if filters.DurationMin != nil || filters.DurationMax != nil {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    durationFilterTag,
+			Clause: querybuilder.Range{Field: "duration", From: intBound(filters.DurationMin), To: intBound(filters.DurationMax)},
+		})
+	}
+
+	if filters.RatingMin != nil {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    ratingFilterTag,
+			Clause: querybuilder.Range{Field: "ratingavg", From: floatBound(filters.RatingMin), To: querybuilder.Unbounded()},
+		})
+	}
+
+	if filters.State != nil {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    stateFilterTag,
+			Clause: querybuilder.Bool{Field: "state", Value: *filters.State},
+		})
+	}
+
+	if filters.InitDateFrom != "" || filters.InitDateTo != "" {
+		// El límite superior es el día siguiente exclusivo para incluir cualquier hora del último día
+		from, to := querybuilder.Unbounded(), querybuilder.Unbounded()
+		if day, err := time.Parse("2006-01-02", filters.InitDateFrom); err == nil {
+			from = querybuilder.DateBound(day)
+		}
+		if day, err := time.Parse("2006-01-02", filters.InitDateTo); err == nil {
+			to = querybuilder.DateBound(day.AddDate(0, 0, 1))
+		}
+		fqs = append(fqs, querybuilder.Filter{
+			Tag:    initDateFilterTag,
+			Clause: querybuilder.Range{Field: "init_date", From: from, To: to, ExclusiveTo: true},
+		})
+	}
+
+	if window := filters.StartsWithin; window != nil {
+		fqs = append(fqs, querybuilder.Filter{
+			Tag: initDateFilterTag,
+			Clause: querybuilder.Range{
+				Field: "init_date",
+				From:  querybuilder.TodayBound(0, querybuilder.Days),
+				To:    querybuilder.TodayBound(window.Amount, dateUnits[window.Unit]),
+			},
+		})
+	}
+
+	if filters.HasCapacity != nil {
+		clause := querybuilder.Range{Field: "capacity", From: querybuilder.IntBound(1), To: querybuilder.Unbounded()}
+		if !*filters.HasCapacity {
+			clause = querybuilder.Range{Field: "capacity", From: querybuilder.Unbounded(), To: querybuilder.IntBound(0)}
+		}
+		fqs = append(fqs, querybuilder.Filter{Tag: capacityFilterTag, Clause: clause})
+	}
+
+	return fqs
+}
+
+func floatBound(bound *float64) querybuilder.Bound {
+	if bound == nil {
+		return querybuilder.Unbounded()
+	}
+	return querybuilder.FloatBound(*bound)
+}
+
+func intBound(bound *int) querybuilder.Bound {
+	if bound == nil {
+		return querybuilder.Unbounded()
+	}
+	return querybuilder.IntBound(*bound)
+}
@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vanng822/go-solr/solr"
+)
+
+// Campos sobre los que se generan fragmentos resaltados
+var highlightFields = []string{"course_name", "description"}
+
+// addHighlightParams pide a Solr fragmentos resaltados con el highlighter unificado
+func (s *SolrClient) addHighlightParams(solrQuery *solr.Query) {
+	solrQuery.SetParam("hl", "true")
+	solrQuery.SetParam("hl.method", "unified")
+	solrQuery.SetParam("hl.fl", strings.Join(highlightFields, ","))
+	solrQuery.SetParam("hl.fragsize", strconv.Itoa(s.config.HighlightFragSize))
+	solrQuery.SetParam("hl.snippets", strconv.Itoa(s.config.HighlightSnippets))
+	solrQuery.SetParam("hl.tag.pre", s.config.HighlightPreTag)
+	solrQuery.SetParam("hl.tag.post", s.config.HighlightPostTag)
+	// Escapa el HTML del texto indexado; solo las etiquetas de resaltado llegan sin escapar
+	solrQuery.SetParam("hl.encoder", "html")
+}
+
+// parseHighlights devuelve los fragmentos de un documento por campo.
+// Solr responde {"highlighting": {id: {campo: [fragmentos]}}}.
+func parseHighlights(res *solr.SolrResult, id string) map[string][]string {
+	highlights := map[string][]string{}
+	byField, _ := res.Highlighting[id].(map[string]interface{})
+	for _, field := range highlightFields {
+		snippets, _ := byField[field].([]interface{})
+		for _, snippet := range snippets {
+			if text, ok := snippet.(string); ok {
+				highlights[field] = append(highlights[field], text)
+			}
+		}
+	}
+	return highlights
+}
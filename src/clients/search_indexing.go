@@ -0,0 +1,170 @@
+package clients
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"search-courses-api/src/config/search"
+	"search-courses-api/src/models"
+
+	"github.com/vanng822/go-solr/solr"
+	"go.uber.org/zap"
+)
+
+// AddCourses indexa los cursos en lotes de IndexBatchSize documentos y hace
+// un único commit al final según la política configurada. Un lote rechazado
+// no detiene la carga: queda registrado en el reporte con sus cursos.
+func (s *SolrClient) AddCourses(courses []models.SearchCourseModel) (*models.IndexReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return nil, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	report := &models.IndexReport{Total: len(courses)}
+	batchSize := s.config.IndexBatchSize
+	for start := 0; start < len(courses); start += batchSize {
+		end := start + batchSize
+		if end > len(courses) {
+			end = len(courses)
+		}
+		report.Batches++
+
+		docs := make([]solr.Document, 0, end-start)
+		courseIDs := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			doc, dateErr := s.courseToDocument(&courses[i])
+			if dateErr != nil {
+				report.InvalidInitDates = append(report.InvalidInitDates, courses[i].ID.Hex())
+			}
+			docs = append(docs, doc)
+			courseIDs = append(courseIDs, courses[i].ID.Hex())
+		}
+
+		if err := s.addDocuments(docs); err != nil {
+			s.logger.Error("[SEARCH-API] Error al indexar lote de cursos en Solr",
+				zap.Int("lote", report.Batches),
+				zap.Strings("course_ids", courseIDs),
+				zap.Error(err))
+			report.Failures = append(report.Failures, models.IndexBatchFailure{
+				Batch:     report.Batches,
+				CourseIDs: courseIDs,
+				Error:     err.Error(),
+			})
+			continue
+		}
+
+		report.Indexed += len(docs)
+		s.logger.Debug("[SEARCH-API] Lote de cursos indexado en Solr",
+			zap.Int("lote", report.Batches),
+			zap.Int("cursos", len(docs)))
+	}
+
+	if report.Indexed > 0 {
+		if err := s.commit(); err != nil {
+			s.logger.Error("Error al hacer commit en Solr", zap.Error(err))
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// courseToDocument arma el documento de Solr de un curso. Una fecha de inicio
+// no reconocida no impide indexarlo: se omite init_date y se devuelve el error.
+func (s *SolrClient) courseToDocument(course *models.SearchCourseModel) (solr.Document, error) {
+	doc := solr.Document{
+		"id":            course.ID.Hex(),
+		"course_name":   course.CourseName,
+		"description":   course.CourseDescription,
+		"price":         course.CoursePrice,
+		"duration":      course.CourseDuration,
+		"state":         course.CourseState,
+		"capacity":      course.CourseCapacity,
+		"image":         course.CourseImage,
+		"category_id":   course.CategoryID.Hex(),
+		"category_name": course.CategoryName,
+		"ratingavg":     course.RatingAvg,
+	}
+
+	if course.CourseInitDate == "" {
+		return doc, nil
+	}
+	initDate, err := s.parseInitDate(course.CourseInitDate)
+	if err != nil {
+		s.logger.Warn("[SEARCH-API] Fecha de inicio no reconocida, se indexa el curso sin init_date",
+			zap.String("course_id", course.ID.Hex()),
+			zap.String("init_date", course.CourseInitDate),
+			zap.Strings("formatos", s.config.InitDateFormats))
+		return doc, err
+	}
+	doc["init_date"] = initDate
+	return doc, nil
+}
+
+// addDocuments envía los documentos en un único update
+func (s *SolrClient) addDocuments(docs []solr.Document) error {
+	res, err := s.connection.Add(docs, 0, s.updateParams())
+	if err != nil {
+		return err
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el lote: %v", res.Result)
+	}
+	return nil
+}
+
+// updateParams agrega commitWithin a los updates con la política commit_within,
+// así el commit lo programa Solr a partir del mismo request
+func (s *SolrClient) updateParams() *url.Values {
+	if s.config.IndexCommitPolicy != search.CommitPolicyWithin {
+		return nil
+	}
+	return &url.Values{"commitWithin": {strconv.Itoa(s.config.IndexCommitWithinMs)}}
+}
+
+// commit hace visibles los documentos agregados según la política configurada
+func (s *SolrClient) commit() error {
+	var (
+		res *solr.SolrUpdateResponse
+		err error
+	)
+	switch s.config.IndexCommitPolicy {
+	case search.CommitPolicyWithin:
+		return nil
+	case search.CommitPolicySoft:
+		res, err = s.connection.SoftCommit()
+	default:
+		res, err = s.connection.Commit()
+	}
+	if err != nil {
+		return err
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el commit: %v", res.Result)
+	}
+	return nil
+}
+
+// DeleteCourse quita un curso del índice por id y hace commit según la política configurada
+func (s *SolrClient) DeleteCourse(courseID string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	s.logger.Info("Eliminando curso de Solr",
+		zap.String("course_id", courseID))
+
+	res, err := s.connection.Delete(map[string]interface{}{"id": courseID}, s.updateParams())
+	if err != nil {
+		return err
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el borrado: %v", res.Result)
+	}
+
+	return s.commit()
+}
@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"fmt"
+
+	"search-courses-api/src/models"
+	"search-courses-api/src/querybuilder"
+
+	"go.uber.org/zap"
+)
+
+// Campos que comparan los cursos similares
+var similarFields = []string{"course_name", "description", "category_name"}
+
+// SimilarCourses devuelve los cursos más parecidos al indicado, aplicando los
+// mismos filtros estructurados que la búsqueda. El curso de origen nunca se incluye.
+func (s *SolrClient) SimilarCourses(courseID string, filters models.SearchFilters, limit int) ([]models.SearchCourseModel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return nil, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	fqs := append(buildFilters(filters), querybuilder.Filter{
+		Clause: querybuilder.Not{Clause: querybuilder.Terms{Field: "id", Values: []string{courseID}}},
+	})
+
+	solrQuery, err := querybuilder.Query{
+		MoreLikeThis: &querybuilder.MoreLikeThis{
+			ID:          courseID,
+			Fields:      similarFields,
+			MinTermFreq: 1,
+			MinDocFreq:  1,
+		},
+		Filters: fqs,
+		Rows:    limit,
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("[SEARCH-API] Buscando cursos similares en Solr",
+		zap.String("query", solrQuery.String()))
+
+	res, err := s.connection.Search(solrQuery).Result(nil)
+	if err == nil {
+		err = solrResultError(res)
+	}
+	if err != nil {
+		s.logger.Error("[SEARCH-API] Error al buscar cursos similares en Solr",
+			zap.String("course_id", courseID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	courses := []models.SearchCourseModel{}
+	if res == nil || res.Results == nil {
+		return courses, nil
+	}
+	for _, doc := range res.Results.Docs {
+		courses = append(courses, documentToCourse(doc))
+	}
+	return courses, nil
+}
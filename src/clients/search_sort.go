@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"search-courses-api/src/models"
+	"search-courses-api/src/querybuilder"
+)
+
+// Campo de Solr para cada clave de ordenamiento de la lista blanca.
+// course_name es un campo de texto, por lo que se ordena por su copia sin tokenizar.
+var solrSortFields = map[string]string{
+	models.SortRelevance:  "score",
+	models.SortPrice:      "price",
+	models.SortRating:     "ratingavg",
+	models.SortInitDate:   "init_date",
+	models.SortDuration:   "duration",
+	models.SortCourseName: "course_name_str",
+}
+
+// buildSort arma el ordenamiento de Solr. Siempre agrega id como desempate
+// para que la paginación sea estable entre requests.
+func buildSort(sort []models.SortField) []querybuilder.Sort {
+	clauses := make([]querybuilder.Sort, 0, len(sort)+1)
+	for _, field := range sort {
+		solrField, ok := solrSortFields[field.Field]
+		if !ok {
+			continue
+		}
+		clauses = append(clauses, querybuilder.Sort{
+			Field:      solrField,
+			Descending: field.Descending || field.Field == models.SortRelevance,
+		})
+	}
+
+	if len(clauses) == 0 {
+		clauses = append(clauses, querybuilder.Sort{Field: "score", Descending: true})
+	}
+
+	return append(clauses, querybuilder.Sort{Field: "id"})
+}
@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	"search-courses-api/src/querybuilder"
+
+	"github.com/vanng822/go-solr/solr"
+	"go.uber.org/zap"
+)
+
+// SpellCheck consulta el handler de spellcheck del core y devuelve la mejor
+// collation para el texto, o "" si Solr no propone ninguna corrección
+func (s *SolrClient) SpellCheck(text string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return "", fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	// La query acompaña a spellcheck.q para que Solr solo proponga
+	// collations que efectivamente devuelven resultados
+	solrQuery, err := querybuilder.Query{
+		Text: text,
+		Edismax: querybuilder.Edismax{
+			QueryFields:  s.config.QueryFields,
+			MinimumMatch: s.config.MinimumMatch,
+		},
+	}.Build()
+	if err != nil {
+		return "", err
+	}
+	solrQuery.SetParam("rows", "0")
+	solrQuery.SetParam("spellcheck", "true")
+	solrQuery.SetParam("spellcheck.q", text)
+	solrQuery.SetParam("spellcheck.dictionary", s.config.SpellcheckDictionary)
+	solrQuery.SetParam("spellcheck.count", "5")
+	solrQuery.SetParam("spellcheck.collate", "true")
+	solrQuery.SetParam("spellcheck.maxCollations", "1")
+	solrQuery.SetParam("spellcheck.maxCollationTries", "5")
+
+	search := s.connection.Search(solrQuery)
+	raw, err := search.Resource(s.config.SpellcheckHandler, search.QueryParams())
+	if err != nil {
+		return "", err
+	}
+	res, err := new(solr.StandardResultParser).Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if res.Error != nil {
+		return "", fmt.Errorf("error de spellcheck en Solr: %v", res.Error["msg"])
+	}
+
+	collation := parseCollation(res.SpellCheck)
+	s.logger.Debug("[SEARCH-API] Resultado de spellcheck",
+		zap.String("query", text),
+		zap.String("collation", collation))
+	return collation, nil
+}
+
+// parseCollation lee la primera collation. Solr la devuelve como lista plana
+// ["collation", valor, ...], donde valor es un string o, con
+// spellcheck.collateExtendedResults, un objeto con collationQuery.
+func parseCollation(spellcheck map[string]interface{}) string {
+	collations, _ := spellcheck["collations"].([]interface{})
+	for i := 0; i+1 < len(collations); i += 2 {
+		if collations[i] != "collation" {
+			continue
+		}
+		switch value := collations[i+1].(type) {
+		case string:
+			return unescapeQueryText(value)
+		case map[string]interface{}:
+			if query, ok := value["collationQuery"].(string); ok {
+				return unescapeQueryText(query)
+			}
+		}
+	}
+	return ""
+}
+
+// unescapeQueryText revierte el escapado de querybuilder.EscapeText en la collation
+func unescapeQueryText(query string) string {
+	var unescaped strings.Builder
+	escaped := false
+	for _, r := range query {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unescaped.WriteRune(r)
+	}
+	return strings.TrimSpace(unescaped.String())
+}
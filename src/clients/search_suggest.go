@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	"search-courses-api/src/models"
+	"search-courses-api/src/querybuilder"
+
+	"go.uber.org/zap"
+)
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+)
+
+// SuggestCourses devuelve completions para el prefijo escrito por el usuario a
+// partir de los campos edge n-gram de course_name y category_name
+func (s *SolrClient) SuggestCourses(prefix string, limit int) ([]models.CourseSuggestion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return nil, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	// Se piden más documentos que sugerencias porque varios cursos pueden
+	// compartir la misma categoría y colapsar en una única sugerencia
+	solrQuery, err := querybuilder.Query{
+		Text: prefix,
+		Edismax: querybuilder.Edismax{
+			QueryFields:  "course_name_suggest^2 category_name_suggest",
+			MinimumMatch: "100%",
+		},
+		Sort:   []querybuilder.Sort{{Field: "score", Descending: true}, {Field: "id"}},
+		Fields: []string{"id", "course_name", "category_name"},
+		Rows:   limit * 3,
+	}.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("[SEARCH-API] Ejecutando autocompletado en Solr",
+		zap.String("query", solrQuery.String()))
+
+	res, err := s.connection.Search(solrQuery).Result(nil)
+	if err == nil {
+		err = solrResultError(res)
+	}
+	if err != nil {
+		s.logger.Error("[SEARCH-API] Error al ejecutar autocompletado en Solr",
+			zap.String("prefix", prefix),
+			zap.Error(err))
+		return nil, err
+	}
+
+	suggestions := []models.CourseSuggestion{}
+	if res == nil || res.Results == nil {
+		return suggestions, nil
+	}
+
+	terms := strings.Fields(normalizeSuggestText(prefix))
+	index := map[string]int{}
+	for _, doc := range res.Results.Docs {
+		courseID := getStringValue(doc, "id")
+		for _, field := range []string{"course_name", "category_name"} {
+			text := getStringValue(doc, field)
+			if text == "" || !matchesPrefixes(text, terms) {
+				continue
+			}
+			key := field + ":" + normalizeSuggestText(text)
+			if i, ok := index[key]; ok {
+				suggestions[i].CourseIDs = append(suggestions[i].CourseIDs, courseID)
+				continue
+			}
+			if len(suggestions) == limit {
+				continue
+			}
+			index[key] = len(suggestions)
+			suggestions = append(suggestions, models.CourseSuggestion{
+				Text:      text,
+				Field:     field,
+				CourseIDs: []string{courseID},
+			})
+		}
+	}
+
+	return suggestions, nil
+}
+
+// matchesPrefixes indica si cada término es prefijo de alguna palabra del texto
+func matchesPrefixes(text string, terms []string) bool {
+	words := strings.Fields(normalizeSuggestText(text))
+	for _, term := range terms {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSuggestText replica el análisis de text_suggest: minúsculas y sin acentos
+func normalizeSuggestText(text string) string {
+	return accentFolder.Replace(strings.ToLower(text))
+}
@@ -0,0 +1,156 @@
+package clients
+
+import (
+	"fmt"
+
+	"search-courses-api/src/config/schema"
+	"search-courses-api/src/querybuilder"
+
+	"github.com/vanng822/go-solr/solr"
+	"go.uber.org/zap"
+)
+
+// Documento interno del core donde se registra la versión de esquema aplicada.
+// Se excluye de todas las búsquedas en buildFilters.
+const schemaMetadataID = "_schema_metadata"
+
+// metadataExclusion descarta el documento de metadatos de los resultados
+var metadataExclusion = querybuilder.Filter{
+	Clause: querybuilder.Not{Clause: querybuilder.Terms{Field: "id", Values: []string{schemaMetadataID}}},
+}
+
+// SchemaVersion devuelve la última migración aplicada en el core, 0 si nunca se migró
+func (s *SolrClient) SchemaVersion() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return 0, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	return s.schemaVersion()
+}
+
+// schemaVersion lee la versión registrada; quien lo llama debe tener tomado s.mu
+func (s *SolrClient) schemaVersion() (int, error) {
+	solrQuery, err := querybuilder.Query{
+		Filters: []querybuilder.Filter{
+			{Clause: querybuilder.Terms{Field: "id", Values: []string{schemaMetadataID}}},
+		},
+		Fields: []string{"id", "schema_version"},
+		Rows:   1,
+	}.Build()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.connection.Search(solrQuery).Result(nil)
+	if err == nil {
+		// Una respuesta de error no significa "nunca migrado": leerla como
+		// versión 0 volvería a aplicar todas las migraciones
+		err = solrResultError(res)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error al leer la versión de esquema de Solr: %v", err)
+	}
+	if res == nil || res.Results == nil || len(res.Results.Docs) == 0 {
+		return 0, nil
+	}
+	return getIntValue(res.Results.Docs[0], "schema_version"), nil
+}
+
+// SetSchemaVersion registra la versión aplicada en el documento de metadatos
+func (s *SolrClient) SetSchemaVersion(version int) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	return s.setSchemaVersion(version)
+}
+
+// setSchemaVersion escribe el documento de metadatos; quien lo llama debe tener tomado s.mu
+func (s *SolrClient) setSchemaVersion(version int) error {
+	doc := solr.Document{
+		"id":             schemaMetadataID,
+		"schema_version": version,
+	}
+	res, err := s.connection.Add([]solr.Document{doc}, 0, nil)
+	if err != nil {
+		return fmt.Errorf("error al registrar la versión de esquema en Solr: %v", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el registro de la versión de esquema: %v", res.Result)
+	}
+	res, err = s.connection.Commit()
+	if err != nil {
+		return fmt.Errorf("error al hacer commit de la versión de esquema en Solr: %v", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el commit de la versión de esquema: %v", res.Result)
+	}
+	return nil
+}
+
+// ApplySchemaMigration envía en orden los comandos del paso al Schema API
+func (s *SolrClient) ApplySchemaMigration(migration schema.Migration) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	for _, command := range migration.Commands {
+		s.logger.Info("[SEARCH-API] Aplicando comando de migración de esquema",
+			zap.String("migration", migration.Name),
+			zap.Any("command", command))
+
+		if err := s.postSchemaCommand(command); err != nil {
+			return fmt.Errorf("migración %s: %v", migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// PurgeDocuments borra todos los documentos de cursos del core y conserva la versión de esquema
+func (s *SolrClient) PurgeDocuments() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	return s.purgeDocuments()
+}
+
+// purgeDocuments borra todo el core; quien lo llama debe tener tomado s.mu.
+// El borrado por *:* hace que Solr descarte los segmentos y con ellos los
+// tipos de docValues registrados para cada campo. Excluir el documento de
+// metadatos del borrado lo impediría, así que se lee la versión antes y se
+// vuelve a registrar después.
+func (s *SolrClient) purgeDocuments() error {
+	version, err := s.schemaVersion()
+	if err != nil {
+		return err
+	}
+
+	res, err := s.connection.Delete(map[string]interface{}{"query": "*:*"}, nil)
+	if err != nil {
+		return fmt.Errorf("error al borrar los documentos de Solr: %v", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el borrado de los documentos: %v", res.Result)
+	}
+	res, err = s.connection.Commit()
+	if err != nil {
+		return fmt.Errorf("error al hacer commit del borrado en Solr: %v", err)
+	}
+	if !res.Success {
+		return fmt.Errorf("Solr rechazó el commit del borrado: %v", res.Result)
+	}
+
+	if version == 0 {
+		return nil
+	}
+	return s.setSchemaVersion(version)
+}
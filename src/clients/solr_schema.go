@@ -0,0 +1,308 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"search-courses-api/src/config/schema"
+
+	"github.com/vanng822/go-solr/solr"
+	"go.uber.org/zap"
+)
+
+// SchemaChange es un comando del Schema API necesario para llevar el core al
+// esquema declarativo, con las diferencias que lo motivan
+type SchemaChange struct {
+	Command     string
+	Name        string
+	Differences []string
+	Definition  interface{}
+	// Incompatible indica que el cambio altera el tipo de docValues del campo,
+	// algo que Lucene no admite en un índice con documentos
+	Incompatible bool
+}
+
+type liveSchema struct {
+	fieldTypes    map[string]map[string]interface{}
+	fields        map[string]map[string]interface{}
+	dynamicFields []string
+	copyFields    map[string]bool
+}
+
+// Atributos de un campo cuyo cambio modifica el tipo de docValues
+var docValuesAttributes = []string{"type", "multiValued", "docValues"}
+
+// EnsureSchema compara el esquema del core con el declarativo del repositorio
+// (src/config/schema/schema.json) y agrega o reemplaza los tipos de campo,
+// campos y copyFields que difieren. Devuelve true cuando los documentos ya
+// indexados deben recargarse. Si algún cambio es incompatible con el índice
+// (por ejemplo un campo numérico multivaluado del esquema data-driven que pasa
+// a ser monovaluado) se borran todos los documentos antes de aplicarlo.
+func (s *SolrClient) EnsureSchema() (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.connected {
+		return false, fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	definition, err := schema.LoadDefinition()
+	if err != nil {
+		return false, err
+	}
+
+	live, err := s.loadSchema()
+	if err != nil {
+		return false, err
+	}
+
+	changes := diffSchema(definition, live)
+	if len(changes) == 0 {
+		s.logger.Info("[SEARCH-API] El esquema de Solr ya coincide con el esquema declarativo")
+		return false, nil
+	}
+
+	reindex := false
+	for _, change := range changes {
+		if change.Incompatible {
+			s.logger.Warn("[SEARCH-API] Cambio de esquema incompatible con el índice, se borran los documentos antes de aplicarlo",
+				zap.String("name", change.Name),
+				zap.Strings("differences", change.Differences))
+			if err := s.purgeDocuments(); err != nil {
+				return false, err
+			}
+			reindex = true
+			break
+		}
+	}
+
+	for _, change := range changes {
+		s.logger.Info("[SEARCH-API] Diferencia de esquema en Solr",
+			zap.String("command", change.Command),
+			zap.String("name", change.Name),
+			zap.Strings("differences", change.Differences))
+
+		if err := s.postSchemaCommand(map[string]interface{}{change.Command: change.Definition}); err != nil {
+			return reindex, err
+		}
+
+		if change.Command == "replace-field" || change.Command == "replace-field-type" {
+			reindex = true
+		}
+	}
+
+	s.logger.Info("[SEARCH-API] Esquema de Solr actualizado",
+		zap.Int("cambios", len(changes)),
+		zap.Bool("reindexar", reindex))
+	return reindex, nil
+}
+
+// diffSchema devuelve los comandos necesarios en orden: tipos de campo, campos y copyFields
+func diffSchema(definition *schema.Definition, live *liveSchema) []SchemaChange {
+	var changes []SchemaChange
+
+	for _, fieldType := range definition.FieldTypes {
+		if change, ok := diffDefinition("field-type", fieldType, live.fieldTypes); ok {
+			changes = append(changes, change)
+		}
+	}
+	for _, field := range definition.Fields {
+		if change, ok := diffDefinition("field", field, live.fields); ok {
+			change.Incompatible = incompatibleFieldChange(change, field, live)
+			changes = append(changes, change)
+		}
+	}
+	for _, copyField := range definition.CopyFields {
+		if !live.copyFields[copyField.Source+"->"+copyField.Dest] {
+			changes = append(changes, SchemaChange{
+				Command:     "add-copy-field",
+				Name:        copyField.Source + "->" + copyField.Dest,
+				Differences: []string{"copyField inexistente"},
+				Definition:  copyField,
+			})
+		}
+	}
+
+	return changes
+}
+
+// diffDefinition compara una definición deseada con la del core. Solo se
+// comparan los atributos declarados: los valores por defecto que agrega Solr
+// no se consideran diferencias.
+func diffDefinition(kind string, desired map[string]interface{}, live map[string]map[string]interface{}) (SchemaChange, bool) {
+	name := desired["name"].(string)
+	current, exists := live[name]
+	if !exists {
+		return SchemaChange{
+			Command:     "add-" + kind,
+			Name:        name,
+			Differences: []string{kind + " inexistente"},
+			Definition:  desired,
+		}, true
+	}
+
+	var differences []string
+	for _, key := range sortedKeys(desired) {
+		if !sameDefinition(desired[key], current[key]) {
+			differences = append(differences, fmt.Sprintf("%s: %v -> %v", key, current[key], desired[key]))
+		}
+	}
+	if len(differences) == 0 {
+		return SchemaChange{}, false
+	}
+
+	return SchemaChange{
+		Command:     "replace-" + kind,
+		Name:        name,
+		Differences: differences,
+		Definition:  desired,
+	}, true
+}
+
+// incompatibleFieldChange indica si el cambio de un campo altera su tipo de
+// docValues. Un campo nuevo que coincide con un dynamicField también lo es,
+// porque los documentos existentes pueden haberlo indexado con ese tipo.
+func incompatibleFieldChange(change SchemaChange, desired map[string]interface{}, live *liveSchema) bool {
+	name := change.Name
+	current, exists := live.fields[name]
+	if !exists {
+		for _, pattern := range live.dynamicFields {
+			if matchesDynamicField(name, pattern) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, key := range docValuesAttributes {
+		if _, declared := desired[key]; declared && !sameDefinition(desired[key], current[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDynamicField aplica un patrón de dynamicField (*_str, attr_*)
+func matchesDynamicField(name, pattern string) bool {
+	switch {
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(name, pattern[1:])
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(name, pattern[:len(pattern)-1])
+	}
+	return name == pattern
+}
+
+// sameDefinition compara recursivamente; los escalares se comparan por su
+// representación de texto porque Solr devuelve "true" o true indistintamente
+func sameDefinition(desired, live interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range d {
+			if !sameDefinition(value, l[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !sameDefinition(d[i], l[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return live != nil && fmt.Sprint(desired) == fmt.Sprint(live)
+	}
+}
+
+func sortedKeys(definition map[string]interface{}) []string {
+	keys := make([]string, 0, len(definition))
+	for key := range definition {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadSchema lee del core los tipos de campo y campos por nombre, y sus
+// copyFields con la forma origen->destino
+func (s *SolrClient) loadSchema() (*liveSchema, error) {
+	solrSchema, err := s.connection.Schema()
+	if err != nil {
+		return nil, err
+	}
+	res, err := solrSchema.All()
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el esquema de Solr: %v", err)
+	}
+	raw, ok := res.Response["schema"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("respuesta inesperada del Schema API de Solr")
+	}
+
+	live := &liveSchema{
+		fieldTypes: byName(raw["fieldTypes"]),
+		fields:     byName(raw["fields"]),
+		copyFields: map[string]bool{},
+	}
+	for name := range byName(raw["dynamicFields"]) {
+		live.dynamicFields = append(live.dynamicFields, name)
+	}
+	if list, ok := raw["copyFields"].([]interface{}); ok {
+		for _, item := range list {
+			if copyField, ok := item.(map[string]interface{}); ok {
+				live.copyFields[fmt.Sprintf("%v->%v", copyField["source"], copyField["dest"])] = true
+			}
+		}
+	}
+	return live, nil
+}
+
+// postSchemaCommand envía un comando al Schema API (/schema) y valida la respuesta
+func (s *SolrClient) postSchemaCommand(command map[string]interface{}) error {
+	body, err := json.Marshal(command)
+	if err != nil {
+		return err
+	}
+
+	raw, err := solr.HTTPPost(fmt.Sprintf("%s/%s/schema?wt=json", s.baseURL, s.core), &body,
+		[][]string{{"Content-Type", "application/json"}}, "", "", 0)
+	if err != nil {
+		return fmt.Errorf("error al actualizar el esquema de Solr: %v", err)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return fmt.Errorf("respuesta inválida del Schema API de Solr: %v", err)
+	}
+	if _, failed := response["error"]; failed {
+		return fmt.Errorf("Solr rechazó el cambio de esquema: %v", response["error"])
+	}
+	if _, failed := response["errors"]; failed {
+		return fmt.Errorf("Solr rechazó el cambio de esquema: %v", response["errors"])
+	}
+	return nil
+}
+
+// byName indexa por su atributo name una lista de definiciones del Schema API
+func byName(list interface{}) map[string]map[string]interface{} {
+	definitions := map[string]map[string]interface{}{}
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if entry, ok := item.(map[string]interface{}); ok {
+			if name, ok := entry["name"].(string); ok {
+				definitions[name] = entry
+			}
+		}
+	}
+	return definitions
+}
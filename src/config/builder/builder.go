@@ -4,10 +4,12 @@ import (
 	"search-courses-api/src/clients"
 	"search-courses-api/src/config/envs"
 	"search-courses-api/src/config/rabbitMQ"
+	"search-courses-api/src/config/search"
 	"search-courses-api/src/controllers"
 	"search-courses-api/src/middlewares"
 	"search-courses-api/src/routes"
 	"search-courses-api/src/services"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -15,10 +17,12 @@ import (
 
 type AppBuilder struct {
 	envs          envs.Envs
+	searchConfig  *search.Config
 	rabbitMQ      *rabbitMQ.RabbitMQ
 	solrClient    *clients.SolrClient
 	searchService *services.SearchService
 	searchCtrl    *controllers.SearchController
+	adminCtrl     *controllers.AdminController
 	router        *gin.Engine
 	logger        *zap.Logger
 }
@@ -27,6 +31,7 @@ func BuildApp() *AppBuilder {
 	builder := &AppBuilder{}
 	builder.envs = envs.LoadEnvs(".env")
 	builder.BuildLogger()
+	builder.BuildSearchConfig()
 	builder.BuildRabbitMQ()
 	builder.BuildSolrClient()
 	builder.BuildServices()
@@ -40,12 +45,16 @@ func (b *AppBuilder) BuildLogger() {
 	b.logger = logger
 }
 
+func (b *AppBuilder) BuildSearchConfig() {
+	b.searchConfig = search.LoadConfig(b.envs)
+}
+
 func (b *AppBuilder) BuildRabbitMQ() {
 	b.rabbitMQ = rabbitMQ.NewRabbitMQ()
 }
 
 func (b *AppBuilder) BuildSolrClient() {
-	b.solrClient = clients.NewSolrClient(b.logger)
+	b.solrClient = clients.NewSolrClient(b.logger, b.searchConfig)
 }
 
 func (b *AppBuilder) BuildServices() {
@@ -54,11 +63,12 @@ func (b *AppBuilder) BuildServices() {
 		coursesAPIURL = "http://localhost:4002"
 	}
 
-	b.searchService = services.NewSearchService(b.solrClient, b.logger, coursesAPIURL)
+	b.searchService = services.NewSearchService(b.solrClient, b.logger, coursesAPIURL, b.searchConfig)
 }
 
 func (b *AppBuilder) BuildControllers() {
-	b.searchCtrl = controllers.NewSearchController(b.searchService, b.logger)
+	b.searchCtrl = controllers.NewSearchController(b.searchService, b.logger, b.searchConfig)
+	b.adminCtrl = controllers.NewAdminController(b.searchService, b.rabbitMQ, b.logger)
 }
 
 func (b *AppBuilder) BuildRouter() {
@@ -69,7 +79,15 @@ func (b *AppBuilder) BuildRouter() {
 	b.router.Use(middlewares.ErrorHandlerMiddleware(b.logger))
 	b.router.Use(middlewares.APIKeyAuthMiddleware(b.logger))
 
-	routes.SetupRoutes(b.router, b.searchCtrl)
+	// Sin ADMIN_API_KEY no se exponen las rutas /admin
+	var adminAuth gin.HandlerFunc
+	if adminKey := strings.TrimSpace(b.envs.Get("ADMIN_API_KEY")); adminKey != "" {
+		adminAuth = middlewares.AdminKeyAuthMiddleware(b.logger, adminKey)
+	} else {
+		b.logger.Warn("[SEARCH-API] ADMIN_API_KEY no configurada, rutas /admin deshabilitadas")
+	}
+
+	routes.SetupRoutes(b.router, b.searchCtrl, b.adminCtrl, adminAuth)
 }
 
 func (b *AppBuilder) GetRabbitMQ() *rabbitMQ.RabbitMQ {
@@ -2,12 +2,17 @@ package envs
 
 import (
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Envs interface {
 	Get(key string) string
+	GetInt(key string, fallback int) int
+	GetFloat(key string, fallback float64) float64
+	GetFloatList(key string, fallback []float64) []float64
 }
 
 type envsImpl struct{}
@@ -16,6 +21,50 @@ func (envsImpl) Get(key string) string {
 	return os.Getenv(key)
 }
 
+// GetInt devuelve la variable como entero o fallback si no está definida
+func (e envsImpl) GetInt(key string, fallback int) int {
+	raw := strings.TrimSpace(e.Get(key))
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		panic("Invalid integer in env " + key + ": " + err.Error())
+	}
+	return value
+}
+
+// GetFloat devuelve la variable como número decimal o fallback si no está definida
+func (e envsImpl) GetFloat(key string, fallback float64) float64 {
+	raw := strings.TrimSpace(e.Get(key))
+	if raw == "" {
+		return fallback
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		panic("Invalid number in env " + key + ": " + err.Error())
+	}
+	return value
+}
+
+// GetFloatList devuelve la variable como lista de números separados por comas
+func (e envsImpl) GetFloatList(key string, fallback []float64) []float64 {
+	raw := strings.TrimSpace(e.Get(key))
+	if raw == "" {
+		return fallback
+	}
+	parts := strings.Split(raw, ",")
+	values := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			panic("Invalid number list in env " + key + ": " + err.Error())
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
 func LoadEnvs(filename ...string) Envs {
 	err := godotenv.Load(filename...)
 	if err != nil {
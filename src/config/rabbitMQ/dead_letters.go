@@ -0,0 +1,190 @@
+package rabbitMQ
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"search-courses-api/src/models"
+
+	"github.com/streadway/amqp"
+)
+
+// Header con el identificador estable de un mensaje en la DLQ
+const headerDeadLetterID = "x-dead-letter-id"
+
+// ErrDeadLetterNotFound indica que no hay un mensaje con ese id en la DLQ
+var ErrDeadLetterNotFound = errors.New("mensaje no encontrado en la DLQ")
+
+// ListDeadLetters devuelve hasta limit mensajes de la DLQ sin consumirlos: se
+// leen con basic.get en un canal propio y se devuelven a la cola al cerrarlo
+func (r *RabbitMQ) ListDeadLetters(limit int) ([]models.DeadLetter, error) {
+	ch, err := r.openChannel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	deadLetters := []models.DeadLetter{}
+	for len(deadLetters) < limit {
+		msg, ok, err := ch.Get(r.DeadLetterQueueName, false)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer la DLQ: %v", err)
+		}
+		if !ok {
+			break
+		}
+		deadLetters = append(deadLetters, toDeadLetter(msg))
+	}
+	return deadLetters, nil
+}
+
+// ReplayDeadLetter devuelve a la cola principal el mensaje de la DLQ con el id indicado
+func (r *RabbitMQ) ReplayDeadLetter(id string) (*models.ReplaySummary, error) {
+	summary, err := r.replay(func(msg amqp.Delivery) bool { return deadLetterID(msg) == id }, true)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Replayed+summary.Failed == 0 {
+		return nil, ErrDeadLetterNotFound
+	}
+	return summary, nil
+}
+
+// ReplayAllDeadLetters devuelve a la cola principal todos los mensajes presentes en la DLQ
+func (r *RabbitMQ) ReplayAllDeadLetters() (*models.ReplaySummary, error) {
+	return r.replay(func(amqp.Delivery) bool { return true }, false)
+}
+
+// replay publica en la cola principal los mensajes de la DLQ que cumplen
+// match y confirma la copia de la DLQ recién cuando RabbitMQ confirma la
+// publicación. El resumen informa los reenvíos y los que no se pudieron
+// reenviar, que quedan en la DLQ; el resultado de procesarlos sigue el flujo
+// normal: se procesan en el worker de su curso, con los reintentos completos,
+// y si vuelven a fallar terminan otra vez en la DLQ con el mismo id. Solo se
+// recorren los mensajes presentes al comenzar.
+func (r *RabbitMQ) replay(match func(amqp.Delivery) bool, single bool) (*models.ReplaySummary, error) {
+	ch, err := r.openChannel()
+	if err != nil {
+		return nil, err
+	}
+	// Los mensajes no confirmados, los que no coinciden o no se reenviaron,
+	// vuelven a la cola al cerrar el canal
+	defer ch.Close()
+
+	if err := ch.Confirm(false); err != nil {
+		return nil, fmt.Errorf("error al activar las confirmaciones de publicación: %v", err)
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+
+	queue, err := ch.QueueInspect(r.DeadLetterQueueName)
+	if err != nil {
+		return nil, fmt.Errorf("error al inspeccionar la DLQ: %v", err)
+	}
+
+	summary := &models.ReplaySummary{Failures: []models.ReplayFailure{}}
+	for i := 0; i < queue.Messages; i++ {
+		msg, ok, err := ch.Get(r.DeadLetterQueueName, false)
+		if err != nil {
+			return summary, fmt.Errorf("error al leer la DLQ: %v", err)
+		}
+		if !ok {
+			break
+		}
+		if !match(msg) {
+			continue
+		}
+
+		id := deadLetterID(msg)
+		log.Printf("Reenviando mensaje de la DLQ %s a la cola %s: %s", id, r.QueueName, string(msg.Body))
+
+		if err := r.republish(ch, confirms, msg, id); err != nil {
+			log.Printf("Error al reenviar el mensaje %s de la DLQ: %v", id, err)
+			summary.Failed++
+			summary.Failures = append(summary.Failures, models.ReplayFailure{ID: id, Error: err.Error()})
+		} else {
+			summary.Replayed++
+		}
+		if single {
+			break
+		}
+	}
+
+	log.Printf("Reprocesamiento de la DLQ finalizado: %d mensajes reenviados, %d fallidos",
+		summary.Replayed, summary.Failed)
+	return summary, nil
+}
+
+// republish publica el mensaje en la cola principal con los intentos en cero,
+// espera la confirmación de RabbitMQ y recién entonces confirma la copia de la DLQ
+func (r *RabbitMQ) republish(ch *amqp.Channel, confirms <-chan amqp.Confirmation, msg amqp.Delivery, id string) error {
+	headers := copyHeaders(msg.Headers)
+	headers[headerDeadLetterID] = id
+	delete(headers, headerRetryCount)
+	err := ch.Publish("", r.QueueName, false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("error al publicar en la cola principal: %v", err)
+	}
+	if confirm := <-confirms; !confirm.Ack {
+		return fmt.Errorf("RabbitMQ no confirmó la publicación en la cola principal")
+	}
+	if err := msg.Ack(false); err != nil {
+		return fmt.Errorf("publicado en la cola principal pero no se pudo confirmar la copia de la DLQ: %v", err)
+	}
+	return nil
+}
+
+// openChannel abre un canal independiente del consumidor
+func (r *RabbitMQ) openChannel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	conn := r.connection
+	r.mu.RUnlock()
+	if conn == nil {
+		return nil, fmt.Errorf("conexión a RabbitMQ no establecida")
+	}
+	return conn.Channel()
+}
+
+func toDeadLetter(msg amqp.Delivery) models.DeadLetter {
+	return models.DeadLetter{
+		ID:            deadLetterID(msg),
+		Body:          string(msg.Body),
+		RetryCount:    retryCount(msg.Headers),
+		FailureReason: headerString(msg.Headers, headerFailureReason),
+		LastError:     headerString(msg.Headers, headerLastError),
+		FailedAt:      headerString(msg.Headers, headerFailedAt),
+	}
+}
+
+// deadLetterID usa el id asignado al enviarlo a la DLQ; los mensajes
+// anteriores a ese header se identifican por el hash del cuerpo
+func deadLetterID(msg amqp.Delivery) string {
+	if id := headerString(msg.Headers, headerDeadLetterID); id != "" {
+		return id
+	}
+	sum := sha256.Sum256(msg.Body)
+	return hex.EncodeToString(sum[:8])
+}
+
+func newDeadLetterID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+func headerString(headers amqp.Table, key string) string {
+	if value, ok := headers[key].(string); ok {
+		return value
+	}
+	return ""
+}
@@ -10,12 +10,30 @@ import (
 )
 
 type RabbitMQ struct {
-	connection     *amqp.Connection
-	channel        *amqp.Channel
-	QueueName      string
-	amqpURL        string
-	mu             sync.RWMutex
-	messageHandler func(message string)
+	connection *amqp.Connection
+	channel    *amqp.Channel
+	QueueName  string
+	// Prefijo de las colas de espera de los reintentos, una por demora del
+	// backoff, y cola de mensajes descartados (DLQ)
+	RetryQueueName      string
+	DeadLetterQueueName string
+	amqpURL             string
+	// Intentos totales de un mensaje y backoff exponencial entre reintentos
+	maxAttempts   int
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+	// Workers que procesan mensajes en paralelo y mensajes sin confirmar que
+	// RabbitMQ entrega por adelantado (QoS)
+	consumers int
+	prefetch  int
+	// Ventana en la que los mensajes con la misma clave se agrupan en uno solo
+	// y demora máxima de un grupo desde su primer mensaje; ventana 0 desactiva
+	coalesceWindow   time.Duration
+	coalesceMaxDelay time.Duration
+	counters         consumerCounters
+	mu               sync.RWMutex
+	messageHandler   func(message string) error
+	messageKey       func(message string) string
 }
 
 var instance *RabbitMQ
@@ -31,8 +49,23 @@ func NewRabbitMQ() *RabbitMQ {
 		}
 
 		instance = &RabbitMQ{
-			QueueName: queueName,
-			amqpURL:   amqpURL,
+			QueueName:           queueName,
+			RetryQueueName:      getOrDefault(env, "RABBITMQ_RETRY_QUEUE_NAME", queueName+".retry"),
+			DeadLetterQueueName: getOrDefault(env, "RABBITMQ_DLQ_NAME", queueName+".dlq"),
+			amqpURL:             amqpURL,
+			maxAttempts:         env.GetInt("RABBITMQ_MAX_ATTEMPTS", 5),
+			retryDelay:          time.Duration(env.GetInt("RABBITMQ_RETRY_DELAY_MS", 1000)) * time.Millisecond,
+			retryMaxDelay:       time.Duration(env.GetInt("RABBITMQ_RETRY_MAX_DELAY_MS", 60000)) * time.Millisecond,
+			consumers:           env.GetInt("RABBITMQ_CONSUMERS", 4),
+			prefetch:            env.GetInt("RABBITMQ_PREFETCH", 20),
+			coalesceWindow:      time.Duration(env.GetInt("RABBITMQ_COALESCE_WINDOW_MS", 500)) * time.Millisecond,
+			coalesceMaxDelay:    time.Duration(env.GetInt("RABBITMQ_COALESCE_MAX_DELAY_MS", 5000)) * time.Millisecond,
+		}
+		if instance.consumers < 1 {
+			instance.consumers = 1
+		}
+		if instance.prefetch < instance.consumers {
+			instance.prefetch = instance.consumers
 		}
 
 		go instance.connectWithRetry()
@@ -66,6 +99,9 @@ func (r *RabbitMQ) connectWithRetry() {
 			false,       // no-wait
 			nil,         // arguments
 		)
+		if err == nil {
+			err = r.declareRetryQueues(ch)
+		}
 		if err != nil {
 			log.Printf("Error al declarar la cola en RabbitMQ: %v. Reintentando en 5 segundos...", err)
 			ch.Close()
@@ -113,6 +149,7 @@ func (r *RabbitMQ) startConsuming() {
 	r.mu.RLock()
 	ch := r.channel
 	handler := r.messageHandler
+	key := r.messageKey
 	r.mu.RUnlock()
 
 	if ch == nil || handler == nil {
@@ -120,10 +157,15 @@ func (r *RabbitMQ) startConsuming() {
 		return
 	}
 
+	if err := ch.Qos(r.prefetch, 0, false); err != nil {
+		log.Printf("Error al configurar el prefetch de RabbitMQ: %v", err)
+		return
+	}
+
 	msgs, err := ch.Consume(
 		r.QueueName, // queue
 		"",          // consumer
-		true,        // auto-ack
+		false,       // auto-ack
 		false,       // exclusive
 		false,       // no-local
 		false,       // no-wait
@@ -134,18 +176,19 @@ func (r *RabbitMQ) startConsuming() {
 		return
 	}
 
-	go func() {
-		for msg := range msgs {
-			message := string(msg.Body)
-			log.Printf("Mensaje recibido de RabbitMQ: %s", message)
-			handler(message)
-		}
-	}()
+	go r.dispatch(ch, msgs, handler, key)
 }
 
-func (r *RabbitMQ) ConsumeMessages(handler func(message string)) {
+// ConsumeMessages registra el handler de mensajes. El mensaje se confirma
+// solo si el handler no devuelve error; si falla se reintenta con backoff y,
+// agotados los intentos o ante un error Permanent, se envía a la DLQ.
+// key devuelve la clave del mensaje, con la que se procesa en orden en un
+// mismo worker y se agrupa con los de la misma clave; los mensajes sin clave
+// no se agrupan.
+func (r *RabbitMQ) ConsumeMessages(handler func(message string) error, key func(message string) string) {
 	r.mu.Lock()
 	r.messageHandler = handler
+	r.messageKey = key
 	r.mu.Unlock()
 
 	// Si el canal está listo, comenzar a consumir
@@ -170,3 +213,10 @@ func (r *RabbitMQ) Close() {
 	}
 	log.Println("Conexión a RabbitMQ cerrada")
 }
+
+func getOrDefault(env envs.Envs, key, fallback string) string {
+	if value := env.Get(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,206 @@
+package rabbitMQ
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// Headers con los que se registran los reintentos de un mensaje
+const (
+	headerRetryCount    = "x-retry-count"
+	headerLastError     = "x-last-error"
+	headerFailureReason = "x-failure-reason"
+	headerFailedAt      = "x-failed-at"
+)
+
+// Motivos por los que un mensaje termina en la DLQ
+const (
+	FailureRetriesExhausted = "retries_exhausted"
+	FailurePermanent        = "permanent_error"
+)
+
+// permanentError marca un error que no se resuelve reintentando
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent indica que el mensaje debe ir directo a la DLQ sin reintentos,
+// por ejemplo cuando no puede decodificarse
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// declareRetryQueues declara una cola de espera por cada demora del backoff y
+// la DLQ. Cada cola tiene su propio x-message-ttl, así todos sus mensajes
+// vencen en orden de llegada y uno con demora larga no retiene a los de
+// demora corta; al vencer vuelven a la cola principal.
+func (r *RabbitMQ) declareRetryQueues(ch *amqp.Channel) error {
+	for _, delay := range r.retryDelays() {
+		_, err := ch.QueueDeclare(
+			r.retryQueueName(delay), // name
+			true,                    // durable
+			false,                   // delete when unused
+			false,                   // exclusive
+			false,                   // no-wait
+			amqp.Table{
+				"x-message-ttl":             delay.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": r.QueueName,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := ch.QueueDeclare(
+		r.DeadLetterQueueName, // name
+		true,                  // durable
+		false,                 // delete when unused
+		false,                 // exclusive
+		false,                 // no-wait
+		nil,                   // arguments
+	)
+	return err
+}
+
+// settle confirma el mensaje si se procesó o lo deriva a reintento o a la DLQ.
+// El original se confirma recién después de publicar la copia; si la
+// publicación falla se devuelve a la cola para no perderlo.
+func (r *RabbitMQ) settle(ch *amqp.Channel, msg amqp.Delivery, handlerErr error) {
+	if handlerErr == nil {
+		if err := msg.Ack(false); err != nil {
+			log.Printf("Error al confirmar el mensaje en RabbitMQ: %v", err)
+		}
+		return
+	}
+
+	attempt := retryCount(msg.Headers) + 1
+	var permanent *permanentError
+	var err error
+	switch {
+	case errors.As(handlerErr, &permanent):
+		log.Printf("Error permanente al procesar el mensaje, se envía a la DLQ: %v", handlerErr)
+		err = r.publishDeadLetter(ch, msg, attempt, FailurePermanent, handlerErr)
+	case attempt >= r.maxAttempts:
+		log.Printf("Mensaje sin procesar tras %d intentos, se envía a la DLQ: %v", attempt, handlerErr)
+		err = r.publishDeadLetter(ch, msg, attempt, FailureRetriesExhausted, handlerErr)
+	default:
+		delay := r.backoff(attempt)
+		log.Printf("Error al procesar el mensaje (intento %d de %d), reintentando en %s: %v", attempt, r.maxAttempts, delay, handlerErr)
+		err = r.publishRetry(ch, msg, attempt, delay, handlerErr)
+	}
+
+	if err != nil {
+		log.Printf("Error al derivar el mensaje fallido, se devuelve a la cola: %v", err)
+		if err := msg.Nack(false, true); err != nil {
+			log.Printf("Error al devolver el mensaje a RabbitMQ: %v", err)
+		}
+		return
+	}
+	if err := msg.Ack(false); err != nil {
+		log.Printf("Error al confirmar el mensaje en RabbitMQ: %v", err)
+	}
+}
+
+// backoff duplica la espera en cada intento, hasta retryMaxDelay
+func (r *RabbitMQ) backoff(attempt int) time.Duration {
+	delay := r.retryDelay
+	for i := 1; i < attempt && delay < r.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.retryMaxDelay {
+		delay = r.retryMaxDelay
+	}
+	return delay
+}
+
+// retryDelays devuelve las demoras distintas que puede producir el backoff
+func (r *RabbitMQ) retryDelays() []time.Duration {
+	var delays []time.Duration
+	for attempt := 1; attempt < r.maxAttempts; attempt++ {
+		delay := r.backoff(attempt)
+		if len(delays) == 0 || delays[len(delays)-1] != delay {
+			delays = append(delays, delay)
+		}
+	}
+	return delays
+}
+
+// retryQueueName arma el nombre de la cola de espera de una demora
+func (r *RabbitMQ) retryQueueName(delay time.Duration) string {
+	return fmt.Sprintf("%s.%dms", r.RetryQueueName, delay.Milliseconds())
+}
+
+func (r *RabbitMQ) publishRetry(ch *amqp.Channel, msg amqp.Delivery, attempt int, delay time.Duration, cause error) error {
+	headers := copyHeaders(msg.Headers)
+	headers[headerRetryCount] = int32(attempt)
+	headers[headerLastError] = errorSummary(cause)
+
+	return ch.Publish("", r.retryQueueName(delay), false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         msg.Body,
+	})
+}
+
+func (r *RabbitMQ) publishDeadLetter(ch *amqp.Channel, msg amqp.Delivery, attempt int, reason string, cause error) error {
+	headers := copyHeaders(msg.Headers)
+	headers[headerRetryCount] = int32(attempt)
+	headers[headerLastError] = errorSummary(cause)
+	headers[headerFailureReason] = reason
+	headers[headerFailedAt] = time.Now().UTC().Format(time.RFC3339)
+	if headerString(headers, headerDeadLetterID) == "" {
+		headers[headerDeadLetterID] = newDeadLetterID()
+	}
+
+	return ch.Publish("", r.DeadLetterQueueName, false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         msg.Body,
+	})
+}
+
+// retryCount lee la cantidad de intentos registrada en los headers
+func retryCount(headers amqp.Table) int {
+	switch value := headers[headerRetryCount].(type) {
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	case string:
+		count, _ := strconv.Atoi(value)
+		return count
+	}
+	return 0
+}
+
+func copyHeaders(headers amqp.Table) amqp.Table {
+	copied := amqp.Table{}
+	for key, value := range headers {
+		copied[key] = value
+	}
+	return copied
+}
+
+// errorSummary acota el mensaje de error que se guarda en los headers
+func errorSummary(err error) string {
+	return fmt.Sprintf("%.512s", err.Error())
+}
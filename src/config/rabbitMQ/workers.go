@@ -0,0 +1,175 @@
+package rabbitMQ
+
+import (
+	"hash/fnv"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// ConsumerStats son los contadores del consumo de mensajes desde el inicio
+type ConsumerStats struct {
+	// Mensajes recibidos de la cola
+	Received int64
+	// Ejecuciones del handler, una por grupo de mensajes agrupados
+	Processed int64
+	// Mensajes descartados por existir uno más reciente con la misma clave
+	Coalesced int64
+}
+
+type consumerCounters struct {
+	received  atomic.Int64
+	processed atomic.Int64
+	coalesced atomic.Int64
+}
+
+// Stats devuelve una copia de los contadores del consumidor
+func (r *RabbitMQ) Stats() ConsumerStats {
+	return ConsumerStats{
+		Received:  r.counters.received.Load(),
+		Processed: r.counters.processed.Load(),
+		Coalesced: r.counters.coalesced.Load(),
+	}
+}
+
+// pendingBatch son las entregas de una misma clave que esperan a que venza la ventana
+type pendingBatch struct {
+	deliveries []amqp.Delivery
+	first      time.Time
+	generation int
+	timer      *time.Timer
+}
+
+type flush struct {
+	key        string
+	generation int
+}
+
+// dispatch agrupa las entregas con la misma clave que llegan dentro de la
+// ventana de coalescing y reparte cada grupo a un worker según la clave.
+// Cada worker procesa su cola en orden, por lo que dos actualizaciones del
+// mismo curso nunca se ejecutan en paralelo ni se invierten.
+func (r *RabbitMQ) dispatch(ch *amqp.Channel, msgs <-chan amqp.Delivery, handler func(string) error, key func(string) string) {
+	shards := make([]chan []amqp.Delivery, r.consumers)
+	var wg sync.WaitGroup
+	for i := range shards {
+		// El prefetch ya limita los mensajes en vuelo; el buffer evita que un
+		// worker ocupado bloquee el reparto a los demás
+		shards[i] = make(chan []amqp.Delivery, r.prefetch)
+		wg.Add(1)
+		go func(batches <-chan []amqp.Delivery) {
+			defer wg.Done()
+			for batch := range batches {
+				r.process(ch, batch, handler)
+			}
+		}(shards[i])
+	}
+
+	pending := map[string]*pendingBatch{}
+	flushes := make(chan flush, r.prefetch)
+	send := func(k string, batch []amqp.Delivery) {
+		shards[shardFor(k, len(shards))] <- batch
+	}
+
+	for msgs != nil || len(pending) > 0 {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				// El canal de entregas se cierra al perder la conexión: se
+				// procesan los grupos pendientes sin esperar la ventana
+				msgs = nil
+				for k, batch := range pending {
+					batch.timer.Stop()
+					delete(pending, k)
+					send(k, batch.deliveries)
+				}
+				continue
+			}
+
+			r.counters.received.Add(1)
+			k := messageKey(msg.Body, key)
+			if k == "" {
+				send(string(msg.Body), []amqp.Delivery{msg})
+				continue
+			}
+			if r.coalesceWindow <= 0 {
+				send(k, []amqp.Delivery{msg})
+				continue
+			}
+
+			batch, exists := pending[k]
+			if !exists {
+				batch = &pendingBatch{first: time.Now()}
+				pending[k] = batch
+			} else {
+				batch.timer.Stop()
+			}
+			batch.deliveries = append(batch.deliveries, msg)
+			batch.generation++
+
+			// Cada mensaje nuevo extiende la ventana, sin superar la demora máxima
+			wait := r.coalesceWindow
+			if deadline := time.Until(batch.first.Add(r.coalesceMaxDelay)); deadline < wait {
+				wait = deadline
+			}
+			pendingFlush := flush{key: k, generation: batch.generation}
+			batch.timer = time.AfterFunc(wait, func() { flushes <- pendingFlush })
+
+		case f := <-flushes:
+			// Un timer detenido tarde puede disparar con una generación vieja
+			batch, exists := pending[f.key]
+			if !exists || batch.generation != f.generation {
+				continue
+			}
+			delete(pending, f.key)
+			send(f.key, batch.deliveries)
+		}
+	}
+
+	for _, shard := range shards {
+		close(shard)
+	}
+	wg.Wait()
+	log.Println("Consumo de mensajes de RabbitMQ detenido")
+}
+
+// process ejecuta el handler una sola vez con el mensaje más reciente del
+// grupo. Los anteriores quedan reemplazados y se confirman sin procesar; el
+// resultado del handler decide la suerte del último (ack, reintento o DLQ).
+func (r *RabbitMQ) process(ch *amqp.Channel, batch []amqp.Delivery, handler func(string) error) {
+	latest := batch[len(batch)-1]
+	message := string(latest.Body)
+	if len(batch) > 1 {
+		log.Printf("Se agruparon %d mensajes de RabbitMQ, se procesa el más reciente: %s", len(batch), message)
+	} else {
+		log.Printf("Mensaje recibido de RabbitMQ: %s", message)
+	}
+
+	r.counters.processed.Add(1)
+	r.settle(ch, latest, handler(message))
+
+	for _, superseded := range batch[:len(batch)-1] {
+		r.counters.coalesced.Add(1)
+		if err := superseded.Ack(false); err != nil {
+			log.Printf("Error al confirmar el mensaje agrupado en RabbitMQ: %v", err)
+		}
+	}
+}
+
+// messageKey obtiene la clave de orden de un mensaje; vacía si no tiene
+func messageKey(body []byte, key func(string) string) string {
+	if key == nil {
+		return ""
+	}
+	return key(string(body))
+}
+
+// shardFor elige el worker de una clave
+func shardFor(key string, shards int) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(shards))
+}
@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pasos de migración numerados del core, con nombre NNNN_descripcion.json.
+// Un paso aplicado nunca se modifica: los cambios posteriores van en un paso nuevo.
+// El bootstrap declarativo (schema.json) corre antes que las migraciones, por
+// lo que los comandos deben ser idempotentes (replace-* en lugar de add-*).
+//
+//go:embed migrations/*.json
+var migrationFiles embed.FS
+
+// Migration es un paso versionado del esquema. Commands son comandos del
+// Schema API que se envían en orden; Reindex indica que los documentos ya
+// indexados deben volver a cargarse para reflejar el cambio. Purge borra los
+// documentos antes de aplicar los comandos, necesario cuando un campo cambia
+// a un tipo incompatible con los valores ya indexados.
+type Migration struct {
+	Version     int                      `json:"-"`
+	Name        string                   `json:"-"`
+	Description string                   `json:"description"`
+	Reindex     bool                     `json:"reindex"`
+	Purge       bool                     `json:"purge"`
+	Commands    []map[string]interface{} `json:"commands"`
+}
+
+// LoadMigrations devuelve los pasos embebidos ordenados por versión. Las
+// versiones deben ser consecutivas desde 1.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error al leer las migraciones de Solr: %v", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migración de Solr con nombre inválido: %s", name)
+		}
+
+		raw, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+		var migration Migration
+		if err := json.Unmarshal(raw, &migration); err != nil {
+			return nil, fmt.Errorf("migración de Solr inválida %s: %v", name, err)
+		}
+		if migration.Purge && !migration.Reindex {
+			return nil, fmt.Errorf("migración de Solr inválida %s: purge requiere reindex", name)
+		}
+		migration.Version = version
+		migration.Name = strings.TrimSuffix(name, ".json")
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migraciones de Solr no consecutivas: se esperaba la versión %d y se encontró %s", i+1, migration.Name)
+		}
+	}
+	return migrations, nil
+}
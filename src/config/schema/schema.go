@@ -0,0 +1,43 @@
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// Esquema declarativo del core de Solr. Es la fuente de verdad de los tipos
+// de campo, campos y copyFields que necesita el servicio.
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// CopyField copia el contenido de Source en Dest al indexar
+type CopyField struct {
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+}
+
+// Definition es el esquema deseado, con las definiciones tal como las acepta el Schema API
+type Definition struct {
+	FieldTypes []map[string]interface{} `json:"fieldTypes"`
+	Fields     []map[string]interface{} `json:"fields"`
+	CopyFields []CopyField              `json:"copyFields"`
+}
+
+// LoadDefinition decodifica el esquema embebido y valida que toda definición tenga nombre
+func LoadDefinition() (*Definition, error) {
+	var definition Definition
+	if err := json.Unmarshal(schemaJSON, &definition); err != nil {
+		return nil, fmt.Errorf("esquema de Solr inválido: %v", err)
+	}
+
+	for _, entries := range [][]map[string]interface{}{definition.FieldTypes, definition.Fields} {
+		for _, entry := range entries {
+			if name, _ := entry["name"].(string); name == "" {
+				return nil, fmt.Errorf("esquema de Solr inválido: definición sin nombre")
+			}
+		}
+	}
+	return &definition, nil
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"search-courses-api/src/config/envs"
+)
+
+// Config reúne los parámetros configurables de las búsquedas en Solr
+type Config struct {
+	// Campos consultados con edismax y sus boosts (qf), boost por frase (pf)
+	// y mínimo de términos que deben coincidir (mm)
+	QueryFields  string
+	PhraseFields string
+	MinimumMatch string
+	// Límites de los buckets de las facetas por rango
+	PriceBuckets    []float64
+	DurationBuckets []float64
+	RatingBuckets   []float64
+	// Cantidad máxima de valores por faceta de campo
+	FacetLimit int
+	// Cantidad de sugerencias por defecto y máxima del autocompletado
+	SuggestLimit    int
+	SuggestMaxLimit int
+	// Handler y diccionario de spellcheck del core; se consulta cuando una
+	// búsqueda devuelve SpellcheckThreshold resultados o menos
+	SpellcheckHandler    string
+	SpellcheckDictionary string
+	SpellcheckThreshold  int
+	// Búsqueda difusa: modo por defecto (off, on, auto), campos en los que se
+	// aplica, boost de la cláusula difusa y largo a partir del cual el modo
+	// auto expande un término
+	FuzzyDefaultMode   string
+	FuzzyFields        []string
+	FuzzyBoost         float64
+	FuzzyAutoMinLength int
+	// Cantidad de cursos similares por defecto y máxima
+	SimilarLimit    int
+	SimilarMaxLimit int
+	// Exportación: cursos pedidos a Solr por iteración y columnas por defecto
+	ExportChunkSize int
+	ExportFields    []string
+	// Resaltado: tamaño de fragmento, cantidad de fragmentos y etiquetas
+	HighlightFragSize int
+	HighlightSnippets int
+	HighlightPreTag   string
+	HighlightPostTag  string
+	// Layouts de Go aceptados para la fecha de inicio que envía courses-api,
+	// probados en orden; los valores sin zona horaria se interpretan en UTC
+	InitDateFormats []string
+	// Indexación: documentos por lote y política de commit (commit_within,
+	// soft o hard); con commit_within Solr hace visible cada lote en el plazo indicado
+	IndexBatchSize      int
+	IndexCommitPolicy   string
+	IndexCommitWithinMs int
+}
+
+// Políticas de commit al indexar cursos
+const (
+	CommitPolicyWithin = "commit_within"
+	CommitPolicySoft   = "soft"
+	CommitPolicyHard   = "hard"
+)
+
+func LoadConfig(env envs.Envs) *Config {
+	return &Config{
+		QueryFields:     getOrDefault(env, "SEARCH_QF", "course_name^4 category_name^2 description"),
+		PhraseFields:    getOrDefault(env, "SEARCH_PF", "course_name^8 description^2"),
+		MinimumMatch:    getOrDefault(env, "SEARCH_MM", "2<75%"),
+		PriceBuckets:    sortedBuckets(env.GetFloatList("SEARCH_FACET_PRICE_BUCKETS", []float64{0, 25, 50, 100, 200})),
+		DurationBuckets: sortedBuckets(env.GetFloatList("SEARCH_FACET_DURATION_BUCKETS", []float64{0, 10, 20, 40, 80})),
+		RatingBuckets:   sortedBuckets(env.GetFloatList("SEARCH_FACET_RATING_BUCKETS", []float64{1, 2, 3, 4})),
+		FacetLimit:      env.GetInt("SEARCH_FACET_LIMIT", 20),
+		SuggestLimit:    env.GetInt("SEARCH_SUGGEST_LIMIT", 5),
+		SuggestMaxLimit: env.GetInt("SEARCH_SUGGEST_MAX_LIMIT", 10),
+
+		SpellcheckHandler:    getOrDefault(env, "SEARCH_SPELLCHECK_HANDLER", "spell"),
+		SpellcheckDictionary: getOrDefault(env, "SEARCH_SPELLCHECK_DICTIONARY", "default"),
+		SpellcheckThreshold:  env.GetInt("SEARCH_SPELLCHECK_THRESHOLD", 2),
+
+		FuzzyDefaultMode:   getOrDefault(env, "SEARCH_FUZZY_DEFAULT_MODE", "off"),
+		FuzzyFields:        strings.Fields(getOrDefault(env, "SEARCH_FUZZY_FIELDS", "course_name category_name")),
+		FuzzyBoost:         env.GetFloat("SEARCH_FUZZY_BOOST", 0.3),
+		FuzzyAutoMinLength: env.GetInt("SEARCH_FUZZY_AUTO_MIN_LENGTH", 5),
+
+		SimilarLimit:    env.GetInt("SEARCH_SIMILAR_LIMIT", 5),
+		SimilarMaxLimit: env.GetInt("SEARCH_SIMILAR_MAX_LIMIT", 20),
+
+		ExportChunkSize: env.GetInt("SEARCH_EXPORT_CHUNK_SIZE", 500),
+		ExportFields:    strings.Split(getOrDefault(env, "SEARCH_EXPORT_FIELDS", "id,course_name,category_name,price,duration,init_date,state,capacity,ratingavg"), ","),
+
+		HighlightFragSize: env.GetInt("SEARCH_HIGHLIGHT_FRAGSIZE", 120),
+		HighlightSnippets: env.GetInt("SEARCH_HIGHLIGHT_SNIPPETS", 2),
+		HighlightPreTag:   getOrDefault(env, "SEARCH_HIGHLIGHT_PRE_TAG", "<em>"),
+		HighlightPostTag:  getOrDefault(env, "SEARCH_HIGHLIGHT_POST_TAG", "</em>"),
+
+		InitDateFormats: strings.Split(getOrDefault(env, "SEARCH_INIT_DATE_FORMATS", "2006-01-02T15:04:05Z07:00;2006-01-02T15:04:05;2006-01-02;02/01/2006"), ";"),
+
+		IndexBatchSize:      positive("SEARCH_INDEX_BATCH_SIZE", env.GetInt("SEARCH_INDEX_BATCH_SIZE", 500)),
+		IndexCommitPolicy:   commitPolicy(getOrDefault(env, "SEARCH_INDEX_COMMIT_POLICY", CommitPolicyWithin)),
+		IndexCommitWithinMs: env.GetInt("SEARCH_INDEX_COMMIT_WITHIN_MS", 1000),
+	}
+}
+
+// positive valida que un tamaño configurado sea mayor que cero
+func positive(key string, value int) int {
+	if value < 1 {
+		panic("Invalid non-positive value in env " + key)
+	}
+	return value
+}
+
+// commitPolicy valida la política de commit configurada
+func commitPolicy(policy string) string {
+	switch policy {
+	case CommitPolicyWithin, CommitPolicySoft, CommitPolicyHard:
+		return policy
+	}
+	panic("Invalid commit policy in env SEARCH_INDEX_COMMIT_POLICY: " + policy)
+}
+
+// sortedBuckets ordena los límites y descarta los repetidos
+func sortedBuckets(bounds []float64) []float64 {
+	sorted := append([]float64(nil), bounds...)
+	sort.Float64s(sorted)
+	unique := make([]float64, 0, len(sorted))
+	for _, bound := range sorted {
+		if len(unique) == 0 || bound != unique[len(unique)-1] {
+			unique = append(unique, bound)
+		}
+	}
+	return unique
+}
+
+func getOrDefault(env envs.Envs, key, fallback string) string {
+	if value := strings.TrimSpace(env.Get(key)); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"net/http"
+	"search-courses-api/src/config/rabbitMQ"
+	"search-courses-api/src/dtos"
+	"search-courses-api/src/errors"
+	"search-courses-api/src/models"
+	"search-courses-api/src/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDeadLetterLimit = 50
+	maxDeadLetterLimit     = 500
+)
+
+type AdminController struct {
+	searchService *services.SearchService
+	rabbitMQ      *rabbitMQ.RabbitMQ
+	logger        *zap.Logger
+}
+
+func NewAdminController(searchService *services.SearchService, rabbitMQ *rabbitMQ.RabbitMQ, logger *zap.Logger) *AdminController {
+	return &AdminController{
+		searchService: searchService,
+		rabbitMQ:      rabbitMQ,
+		logger:        logger,
+	}
+}
+
+func (a *AdminController) SchemaStatus(c *gin.Context) {
+	status, err := a.searchService.SchemaStatus()
+	if err != nil {
+		a.logger.Error("[SEARCH-API] Error al consultar la versión de esquema", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al consultar el esquema de Solr"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SchemaStatusResponseDto{
+		CurrentVersion: status.CurrentVersion,
+		LatestVersion:  status.LatestVersion,
+		Pending:        toMigrationStepsDto(status.Pending),
+	})
+}
+
+func (a *AdminController) MigrateSchema(c *gin.Context) {
+	a.logger.Info("[SEARCH-API] Nueva solicitud de migración de esquema recibida",
+		zap.String("ip", c.ClientIP()))
+
+	result, err := a.searchService.MigrateSchema()
+	if err != nil {
+		a.logger.Error("[SEARCH-API] Error al migrar el esquema", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al migrar el esquema de Solr"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SchemaMigrateResponseDto{
+		FromVersion: result.FromVersion,
+		ToVersion:   result.ToVersion,
+		Applied:     toMigrationStepsDto(result.Applied),
+		Reindexed:   result.Reindexed,
+	})
+}
+
+func (a *AdminController) ListDeadLetters(c *gin.Context) {
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if limit == 0 {
+		limit = defaultDeadLetterLimit
+	}
+	if limit > maxDeadLetterLimit {
+		c.Error(errors.ErrInvalidLimit)
+		return
+	}
+
+	deadLetters, err := a.rabbitMQ.ListDeadLetters(limit)
+	if err != nil {
+		a.logger.Error("[SEARCH-API] Error al listar la cola de mensajes fallidos", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Error al leer la cola de mensajes fallidos"})
+		return
+	}
+
+	messagesDto := make([]dtos.DeadLetterDto, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		messagesDto = append(messagesDto, dtos.DeadLetterDto{
+			Id:            deadLetter.ID,
+			Body:          deadLetter.Body,
+			RetryCount:    deadLetter.RetryCount,
+			FailureReason: deadLetter.FailureReason,
+			LastError:     deadLetter.LastError,
+			FailedAt:      deadLetter.FailedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, dtos.DeadLettersResponseDto{
+		Messages: messagesDto,
+	})
+}
+
+func (a *AdminController) ReplayDeadLetter(c *gin.Context) {
+	id := c.Param("id")
+	a.logger.Info("[SEARCH-API] Nueva solicitud de reprocesamiento de mensaje fallido",
+		zap.String("id", id))
+
+	summary, err := a.rabbitMQ.ReplayDeadLetter(id)
+	if err == rabbitMQ.ErrDeadLetterNotFound {
+		c.Error(errors.ErrDeadLetterNotFound)
+		return
+	}
+	a.replyReplay(c, summary, err)
+}
+
+func (a *AdminController) ReplayAllDeadLetters(c *gin.Context) {
+	a.logger.Info("[SEARCH-API] Nueva solicitud de reprocesamiento de todos los mensajes fallidos")
+
+	summary, err := a.rabbitMQ.ReplayAllDeadLetters()
+	a.replyReplay(c, summary, err)
+}
+
+func (a *AdminController) replyReplay(c *gin.Context, summary *models.ReplaySummary, err error) {
+	if err != nil {
+		a.logger.Error("[SEARCH-API] Error al reprocesar mensajes fallidos", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Error al reprocesar la cola de mensajes fallidos"})
+		return
+	}
+
+	failuresDto := make([]dtos.ReplayFailureDto, 0, len(summary.Failures))
+	for _, failure := range summary.Failures {
+		failuresDto = append(failuresDto, dtos.ReplayFailureDto{
+			Id:    failure.ID,
+			Error: failure.Error,
+		})
+	}
+
+	c.JSON(http.StatusOK, dtos.ReplaySummaryResponseDto{
+		Replayed: summary.Replayed,
+		Failed:   summary.Failed,
+		Failures: failuresDto,
+	})
+}
+
+func (a *AdminController) ConsumerStats(c *gin.Context) {
+	stats := a.rabbitMQ.Stats()
+	c.JSON(http.StatusOK, dtos.ConsumerStatsResponseDto{
+		Received:  stats.Received,
+		Processed: stats.Processed,
+		Coalesced: stats.Coalesced,
+	})
+}
+
+func toMigrationStepsDto(steps []models.SchemaMigrationStep) []dtos.SchemaMigrationStepDto {
+	stepsDto := make([]dtos.SchemaMigrationStepDto, 0, len(steps))
+	for _, step := range steps {
+		stepsDto = append(stepsDto, dtos.SchemaMigrationStepDto{
+			Version:     step.Version,
+			Name:        step.Name,
+			Description: step.Description,
+			Reindex:     step.Reindex,
+			Purge:       step.Purge,
+		})
+	}
+	return stepsDto
+}
@@ -2,8 +2,12 @@ package controllers
 
 import (
 	"net/http"
+	"search-courses-api/src/config/search"
 	"search-courses-api/src/dtos"
+	"search-courses-api/src/errors"
+	"search-courses-api/src/models"
 	"search-courses-api/src/services"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -12,21 +16,38 @@ import (
 type SearchController struct {
 	searchService *services.SearchService
 	logger        *zap.Logger
+	config        *search.Config
 }
 
-func NewSearchController(searchService *services.SearchService, logger *zap.Logger) *SearchController {
+func NewSearchController(searchService *services.SearchService, logger *zap.Logger, config *search.Config) *SearchController {
 	return &SearchController{
 		searchService: searchService,
 		logger:        logger,
+		config:        config,
 	}
 }
 
 func (s *SearchController) SearchCourses(c *gin.Context) {
-	query := c.Query("q")
+	params, err := parseSearchCoursesQuery(c)
+	if err != nil {
+		s.logger.Warn("[SEARCH-API] Parámetros de búsqueda inválidos",
+			zap.String("raw_query", c.Request.URL.RawQuery),
+			zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	query := params.Query
 	s.logger.Info("[SEARCH-API] Nueva solicitud de búsqueda recibida",
-		zap.String("query", query))
+		zap.String("query", query),
+		zap.Int("page", params.Page),
+		zap.Int("page_size", params.PageSize))
 
-	courses, err := s.searchService.SearchCourses(query)
+	result, err := s.searchService.SearchCourses(*params)
+	if appErr, ok := err.(*errors.Error); ok {
+		c.Error(appErr)
+		return
+	}
 	if err != nil {
 		s.logger.Error("[SEARCH-API] Error al procesar la búsqueda",
 			zap.String("query", query),
@@ -35,6 +56,7 @@ func (s *SearchController) SearchCourses(c *gin.Context) {
 		return
 	}
 
+	courses := result.Courses
 	s.logger.Debug("[SEARCH-API] Transformando resultados a DTO",
 		zap.Int("total_cursos", len(courses)))
 
@@ -47,30 +69,251 @@ func (s *SearchController) SearchCourses(c *gin.Context) {
 			continue // Saltear cursos con ID inválido
 		}
 
-		courseDto := dtos.SearchCourseDto{
-			CourseId:          course.ID.Hex(),
-			CourseName:        course.CourseName,
-			CourseDescription: course.CourseDescription,
-			CoursePrice:       course.CoursePrice,
-			CourseDuration:    course.CourseDuration,
-			CourseInitDate:    course.CourseInitDate,
-			CourseState:       course.CourseState,
-			CourseCapacity:    course.CourseCapacity,
-			CourseImage:       course.CourseImage,
-			CategoryID:        course.CategoryID.Hex(),
-			CategoryName:      course.CategoryName,
-			RatingAvg:         course.RatingAvg,
-		}
-		coursesDto = append(coursesDto, courseDto)
+		coursesDto = append(coursesDto, toSearchCourseDto(course))
 	}
 
 	responseDto := dtos.SearchCoursesResponseDto{
-		Courses: coursesDto,
+		Courses:       coursesDto,
+		Total:         result.Total,
+		Page:          params.Page,
+		PageSize:      params.PageSize,
+		TotalPages:    (result.Total + params.PageSize - 1) / params.PageSize,
+		DidYouMean:    result.DidYouMean,
+		AutoCorrected: result.AutoCorrected,
+	}
+	if result.Facets != nil {
+		responseDto.Facets = toSearchFacetsDto(result.Facets)
+	}
+	if result.NextCursor != "" {
+		responseDto.NextCursor = encodeCursor(result.NextCursor, params.Sort)
 	}
 
 	s.logger.Info("[SEARCH-API] Búsqueda completada exitosamente",
 		zap.String("query", query),
-		zap.Int("resultados", len(coursesDto)))
+		zap.Int("resultados", len(coursesDto)),
+		zap.Int("total", result.Total))
 
 	c.JSON(http.StatusOK, responseDto)
 }
+
+func (s *SearchController) ExportCourses(c *gin.Context) {
+	params, err := parseSearchCoursesQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	format, err := parseExportFormat(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	columns, err := parseExportColumns(c.Query("fields"), s.config.ExportFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	s.logger.Info("[SEARCH-API] Nueva solicitud de exportación recibida",
+		zap.String("query", params.Query),
+		zap.String("format", format),
+		zap.Strings("fields", columns))
+
+	writer := newExportWriter(c, format, columns)
+	started := false
+	err = s.searchService.ExportCourses(*params, func(courses []models.SearchCourseModel) error {
+		// Los headers se envían con el primer bloque para poder responder
+		// con un error normal si la búsqueda falla antes de empezar
+		if !started {
+			started = true
+			c.Header("Content-Type", exportContentTypes[format])
+			c.Header("Content-Disposition", "attachment; filename=courses."+format)
+			c.Status(http.StatusOK)
+			if err := writer.writeHeader(); err != nil {
+				return err
+			}
+		}
+		for _, course := range courses {
+			if course.ID.IsZero() {
+				continue
+			}
+			if err := writer.writeCourse(toSearchCourseDto(course)); err != nil {
+				return err
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		if !started {
+			c.Error(err)
+			return
+		}
+		// La respuesta ya está en curso: se corta la conexión para que el
+		// cliente no reciba el archivo truncado como si estuviera completo
+		s.logger.Error("[SEARCH-API] Exportación interrumpida",
+			zap.String("query", params.Query),
+			zap.Error(err))
+		abortStream(c)
+		return
+	}
+
+	if !started {
+		// Sin resultados: se responde el archivo vacío (solo encabezado en CSV)
+		c.Header("Content-Type", exportContentTypes[format])
+		c.Header("Content-Disposition", "attachment; filename=courses."+format)
+		c.Status(http.StatusOK)
+		if err := writer.writeHeader(); err == nil {
+			writer.flush()
+		}
+	}
+}
+
+func (s *SearchController) GetCourse(c *gin.Context) {
+	courseID, err := parseCourseID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	s.logger.Info("[SEARCH-API] Nueva solicitud de curso recibida",
+		zap.String("course_id", courseID))
+
+	course, err := s.searchService.GetCourse(courseID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.SearchCourseResponseDto{
+		Course: toSearchCourseDto(*course),
+	})
+}
+
+func (s *SearchController) SimilarCourses(c *gin.Context) {
+	courseID, err := parseCourseID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filters, err := parseSearchFilters(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	s.logger.Info("[SEARCH-API] Nueva solicitud de cursos similares recibida",
+		zap.String("course_id", courseID),
+		zap.Int("limit", limit))
+
+	courses, err := s.searchService.SimilarCourses(courseID, *filters, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	coursesDto := make([]dtos.SearchCourseDto, 0, len(courses))
+	for _, course := range courses {
+		if course.ID.IsZero() {
+			continue
+		}
+		coursesDto = append(coursesDto, toSearchCourseDto(course))
+	}
+
+	c.JSON(http.StatusOK, dtos.SimilarCoursesResponseDto{
+		Courses: coursesDto,
+	})
+}
+
+func (s *SearchController) SuggestCourses(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		c.Error(errors.ErrMissingQuery)
+		return
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	suggestions, err := s.searchService.SuggestCourses(prefix, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	suggestionsDto := make([]dtos.SuggestionDto, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		suggestionsDto = append(suggestionsDto, dtos.SuggestionDto{
+			Text:      suggestion.Text,
+			Field:     suggestion.Field,
+			CourseIds: suggestion.CourseIDs,
+		})
+	}
+
+	c.JSON(http.StatusOK, dtos.SuggestResponseDto{
+		Suggestions: suggestionsDto,
+	})
+}
+
+func toSearchCourseDto(course models.SearchCourseModel) dtos.SearchCourseDto {
+	return dtos.SearchCourseDto{
+		CourseId:          course.ID.Hex(),
+		CourseName:        course.CourseName,
+		CourseDescription: course.CourseDescription,
+		CoursePrice:       course.CoursePrice,
+		CourseDuration:    course.CourseDuration,
+		CourseInitDate:    course.CourseInitDate,
+		CourseState:       course.CourseState,
+		CourseCapacity:    course.CourseCapacity,
+		CourseImage:       course.CourseImage,
+		CategoryID:        course.CategoryID.Hex(),
+		CategoryName:      course.CategoryName,
+		RatingAvg:         course.RatingAvg,
+		Highlights:        course.Highlights,
+	}
+}
+
+func toSearchFacetsDto(facets *models.SearchFacets) *dtos.SearchFacetsDto {
+	facetsDto := &dtos.SearchFacetsDto{
+		Fields: make(map[string][]dtos.FacetBucketDto, len(facets.Fields)),
+		Ranges: make(map[string][]dtos.RangeFacetBucketDto, len(facets.Ranges)),
+	}
+
+	for field, buckets := range facets.Fields {
+		bucketsDto := make([]dtos.FacetBucketDto, 0, len(buckets))
+		for _, bucket := range buckets {
+			bucketsDto = append(bucketsDto, dtos.FacetBucketDto{
+				Value: bucket.Value,
+				Count: bucket.Count,
+			})
+		}
+		facetsDto.Fields[field] = bucketsDto
+	}
+
+	for field, buckets := range facets.Ranges {
+		bucketsDto := make([]dtos.RangeFacetBucketDto, 0, len(buckets))
+		for _, bucket := range buckets {
+			bucketsDto = append(bucketsDto, dtos.RangeFacetBucketDto{
+				From:  bucket.From,
+				To:    bucket.To,
+				Count: bucket.Count,
+			})
+		}
+		facetsDto.Ranges[field] = bucketsDto
+	}
+
+	return facetsDto
+}
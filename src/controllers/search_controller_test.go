@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	"search-courses-api/src/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestSearchCoursesMapsSolrErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		solrStatus int
+		solrMsg    string
+		wantStatus int
+	}{
+		{"cursorMark rechazado", http.StatusBadRequest, "Unable to parse 'cursorMark' after totem: value must either be '*' or the totem from a previous search: AoEbogus", http.StatusBadRequest},
+		{"error del core", http.StatusInternalServerError, "core no disponible", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newTestSearchController(t, func(w http.ResponseWriter, r *http.Request) {
+				writeSolrJSON(w, tt.solrStatus, map[string]interface{}{
+					"responseHeader": map[string]interface{}{"status": tt.solrStatus},
+					"error":          map[string]interface{}{"msg": tt.solrMsg, "code": tt.solrStatus},
+				})
+			})
+			server := newTestRouter(func(router *gin.Engine) {
+				router.Use(middlewares.ErrorHandlerMiddleware(zap.NewNop()))
+				router.GET("/search/", controller.SearchCourses)
+			})
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/search/?cursor=" + encodeCursor("AoEbogus", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, se esperaba %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
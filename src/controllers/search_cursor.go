@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"search-courses-api/src/errors"
+	"search-courses-api/src/models"
+)
+
+// Valor de cursor con el que el cliente inicia un recorrido
+const firstCursor = "*"
+
+// cursorToken es el contenido del cursor opaco que recibe el cliente: el
+// cursorMark de Solr y el orden con el que fue generado, que debe repetirse
+// en cada página para que Solr no saltee ni duplique cursos
+type cursorToken struct {
+	Mark string `json:"m"`
+	Sort string `json:"s"`
+}
+
+func encodeCursor(mark string, sort []models.SortField) string {
+	raw, _ := json.Marshal(cursorToken{Mark: mark, Sort: sortSignature(sort)})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor devuelve el cursorMark de Solr contenido en el token
+func decodeCursor(token string, sort []models.SortField) (string, error) {
+	if token == firstCursor {
+		return firstCursor, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errors.ErrInvalidCursor
+	}
+	var cursor cursorToken
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Mark == "" {
+		return "", errors.ErrInvalidCursor
+	}
+	if cursor.Sort != sortSignature(sort) {
+		return "", errors.ErrInvalidCursor
+	}
+	return cursor.Mark, nil
+}
+
+func sortSignature(sort []models.SortField) string {
+	keys := make([]string, len(sort))
+	for i, field := range sort {
+		keys[i] = field.Field
+		if field.Descending {
+			keys[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"search-courses-api/src/dtos"
+	"search-courses-api/src/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+)
+
+var exportContentTypes = map[string]string{
+	exportFormatNDJSON: "application/x-ndjson",
+	exportFormatCSV:    "text/csv; charset=utf-8",
+}
+
+// Columnas exportables, con el mismo nombre que en SearchCourseDto
+var exportColumns = map[string]func(dtos.SearchCourseDto) interface{}{
+	"id":            func(c dtos.SearchCourseDto) interface{} { return c.CourseId },
+	"course_name":   func(c dtos.SearchCourseDto) interface{} { return c.CourseName },
+	"description":   func(c dtos.SearchCourseDto) interface{} { return c.CourseDescription },
+	"price":         func(c dtos.SearchCourseDto) interface{} { return c.CoursePrice },
+	"duration":      func(c dtos.SearchCourseDto) interface{} { return c.CourseDuration },
+	"init_date":     func(c dtos.SearchCourseDto) interface{} { return c.CourseInitDate },
+	"state":         func(c dtos.SearchCourseDto) interface{} { return c.CourseState },
+	"capacity":      func(c dtos.SearchCourseDto) interface{} { return c.CourseCapacity },
+	"image":         func(c dtos.SearchCourseDto) interface{} { return c.CourseImage },
+	"category_id":   func(c dtos.SearchCourseDto) interface{} { return c.CategoryID },
+	"category_name": func(c dtos.SearchCourseDto) interface{} { return c.CategoryName },
+	"ratingavg":     func(c dtos.SearchCourseDto) interface{} { return c.RatingAvg },
+}
+
+// parseExportFormat elige el formato por el parámetro format o, si no está, por el header Accept
+func parseExportFormat(c *gin.Context) (string, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		if _, ok := exportContentTypes[format]; !ok {
+			return "", errors.ErrInvalidExportFormat
+		}
+		return format, nil
+	}
+
+	accept := c.GetHeader("Accept")
+	if strings.Contains(accept, "text/csv") {
+		return exportFormatCSV, nil
+	}
+	return exportFormatNDJSON, nil
+}
+
+// parseExportColumns valida la lista de columnas del parámetro fields
+func parseExportColumns(raw string, defaults []string) ([]string, error) {
+	columns := defaults
+	if strings.TrimSpace(raw) != "" {
+		columns = strings.Split(raw, ",")
+	}
+
+	seen := map[string]bool{}
+	parsed := make([]string, 0, len(columns))
+	for _, column := range columns {
+		column = strings.TrimSpace(column)
+		if _, ok := exportColumns[column]; !ok || seen[column] {
+			return nil, errors.ErrInvalidExportFields
+		}
+		seen[column] = true
+		parsed = append(parsed, column)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.ErrInvalidExportFields
+	}
+	return parsed, nil
+}
+
+// exportWriter escribe cursos en NDJSON o CSV sin acumularlos en memoria
+type exportWriter struct {
+	format  string
+	columns []string
+	csv     *csv.Writer
+	ndjson  io.Writer
+}
+
+func newExportWriter(c *gin.Context, format string, columns []string) *exportWriter {
+	writer := &exportWriter{format: format, columns: columns}
+	if format == exportFormatCSV {
+		writer.csv = csv.NewWriter(c.Writer)
+	} else {
+		writer.ndjson = c.Writer
+	}
+	return writer
+}
+
+func (w *exportWriter) writeHeader() error {
+	if w.csv == nil {
+		return nil
+	}
+	return w.csv.Write(w.columns)
+}
+
+func (w *exportWriter) writeCourse(course dtos.SearchCourseDto) error {
+	if w.csv != nil {
+		record := make([]string, len(w.columns))
+		for i, column := range w.columns {
+			record[i] = formatCSVValue(exportColumns[column](course))
+		}
+		return w.csv.Write(record)
+	}
+
+	// La línea se arma en el orden de columns, como en CSV: un map se
+	// serializaría con las claves en orden alfabético
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(exportColumns[column](course))
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	_, err := w.ndjson.Write(line.Bytes())
+	return err
+}
+
+func (w *exportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// abortStream cierra la conexión de una respuesta ya iniciada sin terminarla.
+// Un panic con http.ErrAbortHandler no alcanza por sí solo: el Recovery de gin
+// lo atrapa y completa la respuesta como si nada.
+func abortStream(c *gin.Context) {
+	c.Abort()
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	conn.Close()
+}
+
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
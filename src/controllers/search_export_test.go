@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"search-courses-api/src/clients"
+	"search-courses-api/src/config/search"
+	"search-courses-api/src/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// newTestSearchController arma el controller con un SolrClient real apuntando
+// a un Solr falso que responde las búsquedas con selectHandler
+func newTestSearchController(t *testing.T, selectHandler http.HandlerFunc) *SearchController {
+	t.Helper()
+
+	solr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/solr/courses/select" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		selectHandler(w, r)
+	}))
+	t.Cleanup(solr.Close)
+
+	solrURL, err := url.Parse(solr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLR_HOST", solrURL.Hostname())
+	t.Setenv("SOLR_PORT", solrURL.Port())
+	t.Setenv("SOLR_CORE", "courses")
+
+	// NewSolrClient carga .env del directorio actual
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/.env", nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	config := &search.Config{
+		QueryFields:     "course_name",
+		ExportChunkSize: 1,
+		ExportFields:    []string{"id", "course_name"},
+	}
+	logger := zap.NewNop()
+	solrClient := clients.NewSolrClient(logger, config)
+	solrClient.WaitForConnection()
+	service := services.NewSearchService(solrClient, logger, "http://courses-api.invalid", config)
+	return NewSearchController(service, logger, config)
+}
+
+// newTestRouter usa Recovery como gin.Default
+func newTestRouter(register func(*gin.Engine)) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	register(router)
+	return httptest.NewServer(router)
+}
+
+func writeSolrJSON(w http.ResponseWriter, status int, body map[string]interface{}) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func TestExportCoursesAbortsWhenAPageFails(t *testing.T) {
+	controller := newTestSearchController(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursorMark") == "*" {
+			writeSolrJSON(w, http.StatusOK, map[string]interface{}{
+				"responseHeader": map[string]interface{}{"status": 0},
+				"response": map[string]interface{}{
+					"numFound": 2,
+					"start":    0,
+					"docs": []interface{}{
+						map[string]interface{}{"id": "64b7f1a2c3d4e5f607182930", "course_name": "Go"},
+					},
+				},
+				"nextCursorMark": "AoEpNjRiN2Yx",
+			})
+			return
+		}
+		writeSolrJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"responseHeader": map[string]interface{}{"status": 500},
+			"error":          map[string]interface{}{"msg": "core no disponible", "code": 500},
+		})
+	})
+	server := newTestRouter(func(router *gin.Engine) {
+		router.GET("/search/export", controller.ExportCourses)
+	})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/search/export?format=ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, se esperaba 200 con el primer bloque ya enviado", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		t.Fatalf("la exportación truncada terminó como completa: %q", body)
+	}
+	if !strings.Contains(string(body), "64b7f1a2c3d4e5f607182930") {
+		t.Fatalf("no llegó el primer bloque antes del corte: %q", body)
+	}
+}
+
+func TestExportCoursesKeepsColumnOrderInNDJSON(t *testing.T) {
+	controller := newTestSearchController(t, func(w http.ResponseWriter, r *http.Request) {
+		writeSolrJSON(w, http.StatusOK, map[string]interface{}{
+			"responseHeader": map[string]interface{}{"status": 0},
+			"response": map[string]interface{}{
+				"numFound": 1,
+				"start":    0,
+				"docs": []interface{}{
+					map[string]interface{}{"id": "64b7f1a2c3d4e5f607182930", "course_name": "Go", "price": 10.5},
+				},
+			},
+			"nextCursorMark": r.URL.Query().Get("cursorMark"),
+		})
+	})
+	server := newTestRouter(func(router *gin.Engine) {
+		router.GET("/search/export", controller.ExportCourses)
+	})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/search/export?format=ndjson&fields=price,id,course_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"price":10.5,"id":"64b7f1a2c3d4e5f607182930","course_name":"Go"}` + "\n"
+	if string(body) != want {
+		t.Fatalf("línea = %q, se esperaba %q", body, want)
+	}
+}
@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"search-courses-api/src/errors"
+	"search-courses-api/src/models"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultPage     = 1
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// Máximo desplazamiento permitido con paginación por offset
+	maxSearchOffset = 10000
+)
+
+// parseSearchCoursesQuery lee y valida los parámetros de búsqueda de la request
+func parseSearchCoursesQuery(c *gin.Context) (*models.SearchCoursesQuery, error) {
+	params := &models.SearchCoursesQuery{
+		Query:         c.Query("q"),
+		Page:          defaultPage,
+		PageSize:      defaultPageSize,
+		IncludeFacets: true,
+		AutoCorrect:   true,
+	}
+
+	if raw := c.Query("autocorrect"); raw != "" {
+		autoCorrect, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.ErrInvalidData
+		}
+		params.AutoCorrect = autoCorrect
+	}
+
+	if raw := c.Query("facets"); raw != "" {
+		includeFacets, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.ErrInvalidData
+		}
+		params.IncludeFacets = includeFacets
+	}
+
+	if raw := c.Query("highlight"); raw != "" {
+		highlight, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.ErrInvalidData
+		}
+		params.Highlight = highlight
+	}
+
+	if raw := c.Query("fuzzy"); raw != "" {
+		fuzzy, err := parseFuzzyMode(raw)
+		if err != nil {
+			return nil, err
+		}
+		params.Fuzzy = fuzzy
+	}
+
+	if raw := c.Query("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return nil, errors.ErrInvalidPage
+		}
+		params.Page = page
+	}
+
+	if raw := c.Query("page_size"); raw != "" {
+		pageSize, err := strconv.Atoi(raw)
+		if err != nil || pageSize < 1 || pageSize > maxPageSize {
+			return nil, errors.ErrInvalidPageSize
+		}
+		params.PageSize = pageSize
+	}
+
+	filters, err := parseSearchFilters(c)
+	if err != nil {
+		return nil, err
+	}
+	params.Filters = *filters
+
+	sort, err := parseSort(c.Query("sort"))
+	if err != nil {
+		return nil, err
+	}
+	params.Sort = sort
+
+	// Con cursor se recorre el resultado completo sin el límite de offset
+	if raw := c.Query("cursor"); raw != "" {
+		if c.Query("page") != "" {
+			return nil, errors.ErrInvalidCursor
+		}
+		cursor, err := decodeCursor(raw, params.Sort)
+		if err != nil {
+			return nil, err
+		}
+		params.Cursor = cursor
+		params.Page = 0
+	} else if params.Page-1 > (maxSearchOffset-params.PageSize)/params.PageSize {
+		return nil, errors.ErrPageOutOfRange
+	}
+
+	return params, nil
+}
+
+// parseFuzzyMode acepta true/false además de los nombres de los modos
+func parseFuzzyMode(raw string) (string, error) {
+	switch strings.ToLower(raw) {
+	case "auto":
+		return models.FuzzyAuto, nil
+	case "on", "true", "1":
+		return models.FuzzyOn, nil
+	case "off", "false", "0":
+		return models.FuzzyOff, nil
+	}
+	return "", errors.ErrInvalidData
+}
+
+// parseSort interpreta claves separadas por comas, con "-" para orden descendente
+// (por ejemplo "-ratingavg,price"). Solo se aceptan campos de la lista blanca.
+func parseSort(raw string) ([]models.SortField, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var sort []models.SortField
+	seen := map[string]bool{}
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		field := models.SortField{Field: key}
+		ascending := false
+		if strings.HasPrefix(key, "-") {
+			field = models.SortField{Field: key[1:], Descending: true}
+		} else if strings.HasPrefix(key, "+") {
+			field.Field = key[1:]
+			ascending = true
+		}
+
+		if !models.IsSortableField(field.Field) || seen[field.Field] {
+			return nil, errors.ErrInvalidSort
+		}
+		// La relevancia siempre ordena de mayor a menor puntaje: "-relevance"
+		// equivale a "relevance" y "+relevance" no está soportado
+		if field.Field == models.SortRelevance {
+			if ascending {
+				return nil, errors.ErrInvalidSort
+			}
+			field.Descending = true
+		}
+		seen[field.Field] = true
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// parseSearchFilters lee los filtros estructurados que se traducen a fq de Solr
+func parseSearchFilters(c *gin.Context) (*models.SearchFilters, error) {
+	filters := &models.SearchFilters{}
+	var err error
+
+	// category_id acepta valores repetidos y separados por comas
+	for _, raw := range c.QueryArray("category_id") {
+		for _, categoryID := range strings.Split(raw, ",") {
+			categoryID = strings.TrimSpace(categoryID)
+			if categoryID == "" {
+				continue
+			}
+			if _, err := primitive.ObjectIDFromHex(categoryID); err != nil {
+				return nil, errors.NewInvalidFilterError("category_id")
+			}
+			filters.CategoryIDs = append(filters.CategoryIDs, categoryID)
+		}
+	}
+
+	if filters.PriceMin, err = parseFloatParam(c, "price_min"); err != nil {
+		return nil, err
+	}
+	if filters.PriceMax, err = parseFloatParam(c, "price_max"); err != nil {
+		return nil, err
+	}
+	if filters.PriceMin != nil && filters.PriceMax != nil && *filters.PriceMin > *filters.PriceMax {
+		return nil, errors.NewInvalidFilterError("price_min")
+	}
+
+	if filters.DurationMin, err = parseIntParam(c, "duration_min"); err != nil {
+		return nil, err
+	}
+	if filters.DurationMax, err = parseIntParam(c, "duration_max"); err != nil {
+		return nil, err
+	}
+	if filters.DurationMin != nil && filters.DurationMax != nil && *filters.DurationMin > *filters.DurationMax {
+		return nil, errors.NewInvalidFilterError("duration_min")
+	}
+
+	if filters.RatingMin, err = parseFloatParam(c, "rating_min"); err != nil {
+		return nil, err
+	}
+	if filters.RatingMin != nil && (*filters.RatingMin < 0 || *filters.RatingMin > 5) {
+		return nil, errors.NewInvalidFilterError("rating_min")
+	}
+
+	if filters.State, err = parseBoolParam(c, "state"); err != nil {
+		return nil, err
+	}
+	if filters.HasCapacity, err = parseBoolParam(c, "has_capacity"); err != nil {
+		return nil, err
+	}
+
+	if filters.InitDateFrom, err = parseDateParam(c, "init_date_from"); err != nil {
+		return nil, err
+	}
+	if filters.InitDateTo, err = parseDateParam(c, "init_date_to"); err != nil {
+		return nil, err
+	}
+	if filters.InitDateFrom != "" && filters.InitDateTo != "" && filters.InitDateFrom > filters.InitDateTo {
+		return nil, errors.NewInvalidFilterError("init_date_from")
+	}
+	if filters.StartsWithin, err = parseDateWindowParam(c, "starts_within"); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+func parseFloatParam(c *gin.Context, name string) (*float64, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		return nil, errors.NewInvalidFilterError(name)
+	}
+	return &value, nil
+}
+
+func parseIntParam(c *gin.Context, name string) (*int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return nil, errors.NewInvalidFilterError(name)
+	}
+	return &value, nil
+}
+
+func parseBoolParam(c *gin.Context, name string) (*bool, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, errors.NewInvalidFilterError(name)
+	}
+	return &value, nil
+}
+
+func parseDateParam(c *gin.Context, name string) (string, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return "", nil
+	}
+	if _, err := time.Parse("2006-01-02", raw); err != nil {
+		return "", errors.NewInvalidFilterError(name)
+	}
+	return raw, nil
+}
+
+// Unidades aceptadas por starts_within; las semanas se convierten a días
+var dateWindowUnits = map[byte]string{
+	'd': models.WindowDays,
+	'w': models.WindowDays,
+	'm': models.WindowMonths,
+	'y': models.WindowYears,
+}
+
+// Máxima cantidad de unidades de una ventana de fechas
+const maxDateWindowAmount = 3650
+
+// parseDateWindowParam interpreta ventanas como 30d, 2w, 3m o 1y
+func parseDateWindowParam(c *gin.Context, name string) (*models.DateWindow, error) {
+	raw := strings.ToLower(strings.TrimSpace(c.Query(name)))
+	if raw == "" {
+		return nil, nil
+	}
+	unit, ok := dateWindowUnits[raw[len(raw)-1]]
+	if !ok {
+		return nil, errors.NewInvalidFilterError(name)
+	}
+	amount, err := strconv.Atoi(raw[:len(raw)-1])
+	if err != nil || amount < 1 || amount > maxDateWindowAmount {
+		return nil, errors.NewInvalidFilterError(name)
+	}
+	if raw[len(raw)-1] == 'w' {
+		amount *= 7
+	}
+	return &models.DateWindow{Amount: amount, Unit: unit}, nil
+}
+
+// parseCourseID valida el parámetro de ruta :id como ObjectID
+func parseCourseID(c *gin.Context) (string, error) {
+	courseID := strings.TrimSpace(c.Param("id"))
+	if courseID == "" {
+		return "", errors.ErrMissingCourseId
+	}
+	if _, err := primitive.ObjectIDFromHex(courseID); err != nil {
+		return "", errors.ErrInvalidCourseId
+	}
+	return courseID, nil
+}
+
+// parseLimit lee el parámetro limit; 0 indica que se use el valor configurado
+func parseLimit(c *gin.Context) (int, error) {
+	raw := c.Query("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, errors.ErrInvalidLimit
+	}
+	return limit, nil
+}
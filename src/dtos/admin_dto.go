@@ -0,0 +1,52 @@
+package dtos
+
+type SchemaMigrationStepDto struct {
+	Version     int    `json:"version"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Reindex     bool   `json:"reindex"`
+	Purge       bool   `json:"purge"`
+}
+
+type SchemaStatusResponseDto struct {
+	CurrentVersion int                      `json:"current_version"`
+	LatestVersion  int                      `json:"latest_version"`
+	Pending        []SchemaMigrationStepDto `json:"pending"`
+}
+
+type SchemaMigrateResponseDto struct {
+	FromVersion int                      `json:"from_version"`
+	ToVersion   int                      `json:"to_version"`
+	Applied     []SchemaMigrationStepDto `json:"applied"`
+	Reindexed   bool                     `json:"reindexed"`
+}
+
+type DeadLetterDto struct {
+	Id            string `json:"id"`
+	Body          string `json:"body"`
+	RetryCount    int    `json:"retry_count"`
+	FailureReason string `json:"failure_reason"`
+	LastError     string `json:"last_error"`
+	FailedAt      string `json:"failed_at,omitempty"`
+}
+
+type DeadLettersResponseDto struct {
+	Messages []DeadLetterDto `json:"messages"`
+}
+
+type ReplayFailureDto struct {
+	Id    string `json:"id"`
+	Error string `json:"error"`
+}
+
+type ReplaySummaryResponseDto struct {
+	Replayed int                `json:"replayed"`
+	Failed   int                `json:"failed"`
+	Failures []ReplayFailureDto `json:"failures"`
+}
+
+type ConsumerStatsResponseDto struct {
+	Received  int64 `json:"received"`
+	Processed int64 `json:"processed"`
+	Coalesced int64 `json:"coalesced"`
+}
@@ -13,6 +13,8 @@ type SearchCourseDto struct {
 	CategoryID        string  `json:"category_id"`
 	CategoryName      string  `json:"category_name"`
 	RatingAvg         float64 `json:"ratingavg"`
+	// Highlights contiene los fragmentos resaltados por campo cuando se pide highlight=true
+	Highlights map[string][]string `json:"highlights,omitempty"`
 }
 
 type SearchCourseResponseDto struct {
@@ -20,5 +22,45 @@ type SearchCourseResponseDto struct {
 }
 
 type SearchCoursesResponseDto struct {
+	Courses    []SearchCourseDto `json:"courses"`
+	Total      int               `json:"total"`
+	Page       int               `json:"page,omitempty"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+	Facets     *SearchFacetsDto  `json:"facets,omitempty"`
+	DidYouMean string            `json:"did_you_mean,omitempty"`
+	// AutoCorrected indica que los cursos son el resultado de buscar did_you_mean
+	AutoCorrected bool `json:"auto_corrected"`
+	// NextCursor es el cursor opaco de la página siguiente en modo cursor
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type SimilarCoursesResponseDto struct {
 	Courses []SearchCourseDto `json:"courses"`
 }
+
+type FacetBucketDto struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type RangeFacetBucketDto struct {
+	From  *float64 `json:"from"`
+	To    *float64 `json:"to"`
+	Count int      `json:"count"`
+}
+
+type SearchFacetsDto struct {
+	Fields map[string][]FacetBucketDto      `json:"fields"`
+	Ranges map[string][]RangeFacetBucketDto `json:"ranges"`
+}
+
+type SuggestionDto struct {
+	Text      string   `json:"text"`
+	Field     string   `json:"field"`
+	CourseIds []string `json:"course_ids"`
+}
+
+type SuggestResponseDto struct {
+	Suggestions []SuggestionDto `json:"suggestions"`
+}
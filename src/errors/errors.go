@@ -24,12 +24,35 @@ func NewError(code, message string, httpStatusCode int) *Error {
 }
 
 var (
-	ErrInvalidData     = NewError("INVALID_DATA", "Datos inválidos", http.StatusBadRequest)
-	ErrUserNotFound    = NewError("USER_NOT_FOUND", "Usuario no encontrado", http.StatusNotFound)
-	ErrCourseNotFound  = NewError("COURSE_NOT_FOUND", "Curso no encontrado", http.StatusNotFound)
-	ErrInternalServer  = NewError("INTERNAL_SERVER_ERROR", "Error interno del servidor", http.StatusInternalServerError)
-	ErrDuplicateEnroll = NewError("DUPLICATE_ENROLL", "El estudiante ya está inscrito en este curso", http.StatusConflict)
-	ErrMissingUserId   = NewError("MISSING_USER_ID", "El ID de usuario es requerido", http.StatusBadRequest)
-	ErrMissingCourseId = NewError("MISSING_COURSE_ID", "El ID del curso es requerido", http.StatusBadRequest)
-	ErrNoResults       = NewError("NO_RESULTS", "No se encontraron resultados", http.StatusNotFound)
+	ErrInvalidData         = NewError("INVALID_DATA", "Datos inválidos", http.StatusBadRequest)
+	ErrUserNotFound        = NewError("USER_NOT_FOUND", "Usuario no encontrado", http.StatusNotFound)
+	ErrCourseNotFound      = NewError("COURSE_NOT_FOUND", "Curso no encontrado", http.StatusNotFound)
+	ErrInternalServer      = NewError("INTERNAL_SERVER_ERROR", "Error interno del servidor", http.StatusInternalServerError)
+	ErrDuplicateEnroll     = NewError("DUPLICATE_ENROLL", "El estudiante ya está inscrito en este curso", http.StatusConflict)
+	ErrMissingUserId       = NewError("MISSING_USER_ID", "El ID de usuario es requerido", http.StatusBadRequest)
+	ErrMissingCourseId     = NewError("MISSING_COURSE_ID", "El ID del curso es requerido", http.StatusBadRequest)
+	ErrInvalidCourseId     = NewError("INVALID_COURSE_ID", "El ID del curso no es un ObjectID válido", http.StatusBadRequest)
+	ErrNoResults           = NewError("NO_RESULTS", "No se encontraron resultados", http.StatusNotFound)
+	ErrInvalidPage         = NewError("INVALID_PAGE", "El parámetro page debe ser un entero mayor o igual a 1", http.StatusBadRequest)
+	ErrInvalidPageSize     = NewError("INVALID_PAGE_SIZE", "El parámetro page_size debe ser un entero entre 1 y 100", http.StatusBadRequest)
+	ErrPageOutOfRange      = NewError("PAGE_OUT_OF_RANGE", "La página solicitada supera el máximo de resultados navegables", http.StatusBadRequest)
+	ErrInvalidFilter       = NewError("INVALID_FILTER", "Filtro de búsqueda inválido", http.StatusBadRequest)
+	ErrInvalidSort         = NewError("INVALID_SORT", "El parámetro sort es inválido", http.StatusBadRequest)
+	ErrInvalidCursor       = NewError("INVALID_CURSOR", "El cursor es inválido o no corresponde al orden solicitado", http.StatusBadRequest)
+	ErrInvalidExportFormat = NewError("INVALID_EXPORT_FORMAT", "Formato de exportación no soportado, use ndjson o csv", http.StatusBadRequest)
+	ErrInvalidExportFields = NewError("INVALID_EXPORT_FIELDS", "El parámetro fields contiene columnas inválidas o repetidas", http.StatusBadRequest)
+	ErrMissingQuery        = NewError("MISSING_QUERY", "El parámetro q es requerido", http.StatusBadRequest)
+	ErrInvalidLimit        = NewError("INVALID_LIMIT", "El parámetro limit está fuera del rango permitido", http.StatusBadRequest)
+	ErrInvalidCourseEvent  = NewError("INVALID_COURSE_EVENT", "El evento de curso es inválido", http.StatusBadRequest)
+	ErrDeadLetterNotFound  = NewError("DEAD_LETTER_NOT_FOUND", "Mensaje no encontrado en la cola de mensajes fallidos", http.StatusNotFound)
 )
+
+// NewInvalidFilterError devuelve un ErrInvalidFilter que indica el parámetro rechazado
+func NewInvalidFilterError(param string) *Error {
+	return NewError(ErrInvalidFilter.Code, fmt.Sprintf("El filtro %s es inválido", param), ErrInvalidFilter.HTTPStatusCode)
+}
+
+// NewInvalidCourseEventError devuelve un ErrInvalidCourseEvent con el motivo del rechazo
+func NewInvalidCourseEventError(reason string) *Error {
+	return NewError(ErrInvalidCourseEvent.Code, fmt.Sprintf("Evento de curso inválido: %s", reason), ErrInvalidCourseEvent.HTTPStatusCode)
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AdminKeyAuthMiddleware exige la credencial de administración en X-Admin-Key,
+// independiente de la API key de búsqueda
+func AdminKeyAuthMiddleware(logger *zap.Logger, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminKey := c.GetHeader("X-Admin-Key")
+
+		if adminKey == "" || subtle.ConstantTimeCompare([]byte(adminKey), []byte(key)) != 1 {
+			logger.Warn("Admin key inválida", zap.String("ip", c.ClientIP()), zap.String("path", c.Request.URL.Path))
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid admin key"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
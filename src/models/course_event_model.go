@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Tipos de evento de cambio de curso publicados por courses-api
+const (
+	CourseCreated       = "course.created"
+	CourseUpdated       = "course.updated"
+	CourseDeleted       = "course.deleted"
+	CourseRatingChanged = "course.rating_changed"
+)
+
+// Versión del sobre de eventos que entiende el servicio
+const CourseEventSchemaVersion = 1
+
+// CourseEvent es el sobre JSON de un cambio de curso. Los mensajes legacy que
+// solo contienen el id del curso se interpretan como CourseUpdated.
+type CourseEvent struct {
+	Type     string `json:"type"`
+	CourseID string `json:"course_id"`
+	// Versión del curso en courses-api y momento del cambio
+	SourceVersion int64     `json:"source_version,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at,omitempty"`
+	SchemaVersion int       `json:"schema_version"`
+	// Nuevo promedio de calificaciones, solo en CourseRatingChanged. Es
+	// informativo: el curso siempre se relee de courses-api.
+	RatingAvg *float64 `json:"ratingavg,omitempty"`
+	// Indica que el evento proviene de un mensaje con el id sin sobre
+	Legacy bool `json:"-"`
+}
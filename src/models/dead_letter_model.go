@@ -0,0 +1,26 @@
+package models
+
+// DeadLetter es un mensaje de la DLQ de actualizaciones de cursos
+type DeadLetter struct {
+	ID            string
+	Body          string
+	RetryCount    int
+	FailureReason string
+	LastError     string
+	FailedAt      string
+}
+
+// ReplayFailure es un mensaje de la DLQ que no se pudo devolver a la cola principal
+type ReplayFailure struct {
+	ID    string
+	Error string
+}
+
+// ReplaySummary resume el reprocesamiento de mensajes de la DLQ: cuántos se
+// devolvieron a la cola principal y cuáles no. El resultado de procesar los
+// reenviados se ve en la DLQ y en las estadísticas del consumidor.
+type ReplaySummary struct {
+	Replayed int
+	Failed   int
+	Failures []ReplayFailure
+}
@@ -0,0 +1,26 @@
+package models
+
+// SchemaMigrationStep describe un paso versionado del esquema de Solr
+type SchemaMigrationStep struct {
+	Version     int
+	Name        string
+	Description string
+	Reindex     bool
+	Purge       bool
+}
+
+// SchemaMigrationStatus compara la versión aplicada en el core con la última disponible
+type SchemaMigrationStatus struct {
+	CurrentVersion int
+	LatestVersion  int
+	Pending        []SchemaMigrationStep
+}
+
+// SchemaMigrationResult resume una ejecución de migrate
+type SchemaMigrationResult struct {
+	FromVersion int
+	ToVersion   int
+	Applied     []SchemaMigrationStep
+	// Indica que se recargaron todos los cursos porque algún paso lo requería
+	Reindexed bool
+}
@@ -15,4 +15,23 @@ type SearchCourseModel struct {
 	CategoryID        primitive.ObjectID `json:"category_id" bson:"category_id"`
 	CategoryName      string             `json:"category_name" bson:"category_name,omitempty"`
 	RatingAvg         float64            `json:"ratingavg" bson:"ratingavg,omitempty"`
+	// Fragmentos resaltados por campo, solo presentes en resultados de búsqueda
+	Highlights map[string][]string `json:"-" bson:"-"`
+}
+
+// IndexBatchFailure es un lote de cursos que Solr rechazó al indexar
+type IndexBatchFailure struct {
+	Batch     int
+	CourseIDs []string
+	Error     string
+}
+
+// IndexReport resume una indexación masiva de cursos
+type IndexReport struct {
+	Total    int
+	Indexed  int
+	Batches  int
+	Failures []IndexBatchFailure
+	// Cursos indexados sin init_date porque la fecha no coincidió con ningún formato
+	InvalidInitDates []string
 }
@@ -0,0 +1,137 @@
+package models
+
+// SearchCoursesQuery agrupa los parámetros de una búsqueda de cursos
+type SearchCoursesQuery struct {
+	Query    string
+	Page     int
+	PageSize int
+	// cursorMark de Solr para paginación profunda; si está presente Page se ignora
+	Cursor  string
+	Filters SearchFilters
+	// Orden de los resultados; vacío usa el orden por relevancia
+	Sort []SortField
+	// Solicitar facetas junto con los resultados
+	IncludeFacets bool
+	// Repetir la búsqueda con la corrección ortográfica si no hay resultados
+	AutoCorrect bool
+	// Modo de búsqueda difusa; vacío usa el modo configurado
+	Fuzzy string
+	// Solicitar fragmentos resaltados de course_name y description
+	Highlight bool
+}
+
+// Modos de búsqueda tolerante a errores de tipeo
+const (
+	FuzzyOff  = "off"
+	FuzzyOn   = "on"
+	FuzzyAuto = "auto"
+)
+
+// Campos por los que se puede ordenar una búsqueda
+const (
+	SortRelevance  = "relevance"
+	SortPrice      = "price"
+	SortRating     = "ratingavg"
+	SortInitDate   = "init_date"
+	SortDuration   = "duration"
+	SortCourseName = "course_name"
+)
+
+var sortableFields = map[string]bool{
+	SortRelevance:  true,
+	SortPrice:      true,
+	SortRating:     true,
+	SortInitDate:   true,
+	SortDuration:   true,
+	SortCourseName: true,
+}
+
+// IsSortableField indica si el campo está en la lista blanca de ordenamiento
+func IsSortableField(field string) bool {
+	return sortableFields[field]
+}
+
+// SortField es una clave de ordenamiento
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// SearchFilters son los filtros estructurados de una búsqueda. Los punteros nil
+// y los strings vacíos indican que el filtro no se aplica.
+type SearchFilters struct {
+	CategoryIDs []string
+	PriceMin    *float64
+	PriceMax    *float64
+	DurationMin *int
+	DurationMax *int
+	RatingMin   *float64
+	State       *bool
+	// Fechas en formato YYYY-MM-DD, ambas inclusive
+	InitDateFrom string
+	InitDateTo   string
+	// Cursos que comienzan entre hoy y hoy más la ventana indicada
+	StartsWithin *DateWindow
+	HasCapacity  *bool
+}
+
+// Unidades de una ventana de fechas relativa a hoy
+const (
+	WindowDays   = "days"
+	WindowMonths = "months"
+	WindowYears  = "years"
+)
+
+// DateWindow es un período relativo a la fecha actual, por ejemplo 30 días
+type DateWindow struct {
+	Amount int
+	Unit   string
+}
+
+// Start devuelve el desplazamiento (start de Solr) correspondiente a la página pedida
+func (q SearchCoursesQuery) Start() int {
+	if q.Page < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PageSize
+}
+
+// SearchCoursesResult contiene una página de cursos y el total de coincidencias en Solr
+type SearchCoursesResult struct {
+	Courses []SearchCourseModel
+	Total   int
+	Facets  *SearchFacets
+	// Corrección sugerida por el spellcheck de Solr, vacía si no hay
+	DidYouMean string
+	// Indica que los resultados corresponden a DidYouMean y no a la query original
+	AutoCorrected bool
+	// cursorMark de la página siguiente, vacío cuando no quedan resultados
+	NextCursor string
+}
+
+// FacetBucket es un valor de una faceta de campo y su cantidad de cursos
+type FacetBucket struct {
+	Value string
+	Count int
+}
+
+// RangeFacetBucket es un intervalo [From, To) de una faceta por rango.
+// Un límite nil indica que el intervalo no está acotado de ese lado.
+type RangeFacetBucket struct {
+	From  *float64
+	To    *float64
+	Count int
+}
+
+// SearchFacets agrupa las facetas de campo y por rango de una búsqueda
+type SearchFacets struct {
+	Fields map[string][]FacetBucket
+	Ranges map[string][]RangeFacetBucket
+}
+
+// CourseSuggestion es una completion de autocompletado y los cursos que la contienen
+type CourseSuggestion struct {
+	Text      string
+	Field     string
+	CourseIDs []string
+}
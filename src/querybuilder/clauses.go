@@ -0,0 +1,173 @@
+package querybuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var fieldNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func validateField(field string) error {
+	if !fieldNamePattern.MatchString(field) {
+		return fmt.Errorf("nombre de campo inválido: %q", field)
+	}
+	return nil
+}
+
+// Clause es una cláusula de filtro que se renderiza en sintaxis de Lucene
+type Clause interface {
+	render() (string, error)
+}
+
+// Terms coincide con cualquiera de los valores: field:(v1 OR v2)
+type Terms struct {
+	Field  string
+	Values []string
+}
+
+func (t Terms) render() (string, error) {
+	if err := validateField(t.Field); err != nil {
+		return "", err
+	}
+	if len(t.Values) == 0 {
+		return "", fmt.Errorf("filtro sin valores para el campo %s", t.Field)
+	}
+	values := make([]string, len(t.Values))
+	for i, value := range t.Values {
+		values[i] = EscapeTerm(value)
+	}
+	return fmt.Sprintf("%s:(%s)", t.Field, strings.Join(values, " OR ")), nil
+}
+
+// Bool coincide con un valor booleano: field:true
+type Bool struct {
+	Field string
+	Value bool
+}
+
+func (b Bool) render() (string, error) {
+	if err := validateField(b.Field); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%t", b.Field, b.Value), nil
+}
+
+// Range coincide con un intervalo. El límite inferior siempre es inclusivo.
+type Range struct {
+	Field       string
+	From        Bound
+	To          Bound
+	ExclusiveTo bool
+}
+
+func (r Range) render() (string, error) {
+	if err := validateField(r.Field); err != nil {
+		return "", err
+	}
+	closing := "]"
+	if r.ExclusiveTo {
+		closing = "}"
+	}
+	return fmt.Sprintf("%s:[%s TO %s%s", r.Field, r.From.String(), r.To.String(), closing), nil
+}
+
+// Not niega una cláusula
+type Not struct {
+	Clause Clause
+}
+
+func (n Not) render() (string, error) {
+	inner, err := n.Clause.render()
+	if err != nil {
+		return "", err
+	}
+	return "(*:* -" + inner + ")", nil
+}
+
+// Bound es un límite de un Range ya escapado
+type Bound struct {
+	value string
+}
+
+func (b Bound) String() string {
+	if b.value == "" {
+		return "*"
+	}
+	return b.value
+}
+
+// Unbounded es un límite abierto (*)
+func Unbounded() Bound {
+	return Bound{}
+}
+
+func FloatBound(value float64) Bound {
+	return Bound{value: strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+func IntBound(value int) Bound {
+	return Bound{value: strconv.Itoa(value)}
+}
+
+// DateBound es un instante exacto, expresado en UTC como lo espera un campo de fecha
+func DateBound(value time.Time) Bound {
+	return Bound{value: value.UTC().Format(time.RFC3339)}
+}
+
+// DateUnit es una unidad de date math de Solr
+type DateUnit string
+
+const (
+	Days   DateUnit = "DAYS"
+	Months DateUnit = "MONTHS"
+	Years  DateUnit = "YEARS"
+)
+
+// TodayBound es el comienzo del día actual desplazado amount unidades
+// (NOW/DAY+30DAYS). Se resuelve en Solr al ejecutar la query y el redondeo
+// al día permite reutilizar el filtro en la caché durante todo el día.
+func TodayBound(amount int, unit DateUnit) Bound {
+	if amount == 0 {
+		return Bound{value: "NOW/DAY"}
+	}
+	return Bound{value: fmt.Sprintf("NOW/DAY%+d%s", amount, unit)}
+}
+
+// Filter es un fq con un tag opcional para excluirlo en facetas
+type Filter struct {
+	Tag    string
+	Clause Clause
+}
+
+func (f Filter) render() (string, error) {
+	clause, err := f.Clause.render()
+	if err != nil {
+		return "", err
+	}
+	if f.Tag == "" {
+		return clause, nil
+	}
+	if err := validateField(f.Tag); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{!tag=%s}%s", f.Tag, clause), nil
+}
+
+// Sort es una clave de ordenamiento
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+func (s Sort) render() (string, error) {
+	if err := validateField(s.Field); err != nil {
+		return "", err
+	}
+	if s.Descending {
+		return s.Field + " desc", nil
+	}
+	return s.Field + " asc", nil
+}
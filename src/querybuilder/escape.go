@@ -0,0 +1,55 @@
+package querybuilder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Caracteres con significado en la sintaxis de Lucene/Solr, incluidos los que
+// abren local params ({!...}) y referencias a parámetros ($param).
+const specialChars = `\+-!():^[]"{}~*?|&/;$=`
+
+// Operadores booleanos que edismax interpreta aunque estén escapados carácter a carácter
+var booleanOperators = map[string]bool{"AND": true, "OR": true, "NOT": true}
+
+// EscapeText escapa texto libre de usuario para usarlo como q de edismax.
+// Conserva los espacios para que edismax separe términos y pasa a minúscula
+// los operadores booleanos, de modo que el resultado siempre sea una lista de
+// términos literales.
+func EscapeText(text string) string {
+	words := strings.Fields(stripControl(text))
+	for i, word := range words {
+		if booleanOperators[word] {
+			word = strings.ToLower(word)
+		}
+		words[i] = escape(word, false)
+	}
+	return strings.Join(words, " ")
+}
+
+// EscapeTerm escapa un valor para usarlo como un único término (campo:valor),
+// incluidos los espacios.
+func EscapeTerm(value string) string {
+	return escape(stripControl(value), true)
+}
+
+func escape(value string, escapeSpaces bool) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(specialChars, r) || (escapeSpaces && unicode.IsSpace(r)) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// stripControl descarta caracteres de control, que no aportan a la búsqueda
+func stripControl(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
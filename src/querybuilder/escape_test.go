@@ -0,0 +1,177 @@
+package querybuilder
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+var escapeSeeds = []string{
+	"",
+	"java",
+	"curso de go",
+	"a AND b OR NOT c",
+	"a && b || !c",
+	`{!lucene}*:*`,
+	`{!edismax qf=id v=$user_q}`,
+	"$user_q",
+	"id:1 OR price:[0 TO *]",
+	`foo" OR "bar`,
+	`\`,
+	`a\ b`,
+	"(a) [b] {c}",
+	"tab\tnewline\nnbsp ",
+	"ctrl\x00\x07",
+	"niño canción",
+}
+
+// unescapedTokens separa s en los espacios no escapados y devuelve, para cada
+// token, los caracteres que Solr interpreta como sintaxis (los no precedidos por \)
+func unescapedTokens(s string) (tokens []string, syntax [][]rune) {
+	var token strings.Builder
+	var current []rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+			token.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			token.WriteRune(r)
+		case unicode.IsSpace(r):
+			tokens = append(tokens, token.String())
+			syntax = append(syntax, current)
+			token.Reset()
+			current = nil
+		default:
+			token.WriteRune(r)
+			current = append(current, r)
+		}
+	}
+	return append(tokens, token.String()), append(syntax, current)
+}
+
+// unescape quita las barras de escape
+func unescape(s string) string {
+	var out strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func assertNoSyntax(t *testing.T, input, output string, syntax []rune) {
+	t.Helper()
+	for _, r := range syntax {
+		if strings.ContainsRune(specialChars, r) {
+			t.Fatalf("entrada %q: carácter %q sin escapar en %q", input, r, output)
+		}
+	}
+}
+
+func FuzzEscapeTerm(f *testing.F) {
+	for _, seed := range escapeSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		output := EscapeTerm(input)
+
+		tokens, syntax := unescapedTokens(output)
+		if len(tokens) != 1 {
+			t.Fatalf("entrada %q: espacio sin escapar en %q", input, output)
+		}
+		assertNoSyntax(t, input, output, syntax[0])
+		if strings.HasSuffix(output, `\`) && !strings.HasSuffix(output, `\\`) {
+			t.Fatalf("entrada %q: barra final sin escapar en %q", input, output)
+		}
+		if unescape(output) != stripControl(input) {
+			t.Fatalf("entrada %q: el término %q no conserva el valor", input, output)
+		}
+	})
+}
+
+func FuzzEscapeText(f *testing.F) {
+	for _, seed := range escapeSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		output := EscapeText(input)
+		if output == "" {
+			return
+		}
+
+		tokens, syntax := unescapedTokens(output)
+		for i, token := range tokens {
+			if token == "" {
+				t.Fatalf("entrada %q: espacios consecutivos o extremos en %q", input, output)
+			}
+			assertNoSyntax(t, input, output, syntax[i])
+			if booleanOperators[token] {
+				t.Fatalf("entrada %q: operador %s sin neutralizar en %q", input, token, output)
+			}
+		}
+		if strings.Contains(output, "\t") || strings.Contains(output, "\n") {
+			t.Fatalf("entrada %q: solo se admiten espacios simples entre términos: %q", input, output)
+		}
+	})
+}
+
+func FuzzTermsRender(f *testing.F) {
+	for _, seed := range escapeSeeds {
+		f.Add(seed, "otro")
+	}
+	f.Fuzz(func(t *testing.T, first, second string) {
+		if stripControl(first) == "" || stripControl(second) == "" {
+			return
+		}
+		output, err := Terms{Field: "category_id", Values: []string{first, second}}.render()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		const prefix = "category_id:("
+		if !strings.HasPrefix(output, prefix) || !strings.HasSuffix(output, ")") {
+			t.Fatalf("valores %q, %q: forma inesperada %q", first, second, output)
+		}
+		inner := output[len(prefix) : len(output)-1]
+		tokens, syntax := unescapedTokens(inner)
+		if len(tokens) != 3 || tokens[1] != "OR" {
+			t.Fatalf("valores %q, %q: los valores escapan de su término en %q", first, second, output)
+		}
+		assertNoSyntax(t, first, output, syntax[0])
+		assertNoSyntax(t, second, output, syntax[2])
+		if unescape(tokens[0]) != stripControl(first) || unescape(tokens[2]) != stripControl(second) {
+			t.Fatalf("valores %q, %q: no se conservan en %q", first, second, output)
+		}
+	})
+}
+
+func FuzzRangeRender(f *testing.F) {
+	f.Add(0.0, int64(0))
+	f.Add(-1.5, int64(-10))
+	f.Add(1e300, int64(1)<<40)
+	f.Fuzz(func(t *testing.T, from float64, to int64) {
+		output, err := Range{Field: "price", From: FloatBound(from), To: IntBound(int(to)), ExclusiveTo: true}.render()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tokens, _ := unescapedTokens(output)
+		if len(tokens) != 3 || tokens[1] != "TO" ||
+			!strings.HasPrefix(tokens[0], "price:[") || !strings.HasSuffix(tokens[2], "}") {
+			t.Fatalf("rango %v a %d: forma inesperada %q", from, to, output)
+		}
+		for _, bound := range []string{tokens[0][len("price:["):], tokens[2][:len(tokens[2])-1]} {
+			if strings.ContainsAny(bound, `{}[]():"$!\`) {
+				t.Fatalf("rango %v a %d: límite con sintaxis en %q", from, to, output)
+			}
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Fuzzy agrega a la búsqueda una cláusula opcional con cada término expandido
+// a un término difuso. Con Boost < 1 las coincidencias aproximadas puntúan por
+// debajo de las exactas, que suman el puntaje de ambas cláusulas.
+type Fuzzy struct {
+	Fields []string
+	Boost  float64
+	// Largo mínimo (en caracteres) de un término para expandirlo
+	MinLength int
+}
+
+// FuzzyDistance devuelve la distancia de edición según el largo del término
+func FuzzyDistance(term string) int {
+	if len([]rune(term)) >= 6 {
+		return 2
+	}
+	return 1
+}
+
+// clause arma la cláusula difusa en sintaxis de Lucene. Cada término que
+// alcanza el largo mínimo debe coincidir aproximadamente en alguno de los
+// campos; los términos cortos (artículos, preposiciones) quedan solo en la
+// cláusula exacta. Devuelve "" si ningún término alcanza el largo mínimo.
+func (f Fuzzy) clause(text string) (string, error) {
+	for _, field := range f.Fields {
+		if err := validateField(field); err != nil {
+			return "", err
+		}
+	}
+	if len(f.Fields) == 0 {
+		return "", nil
+	}
+
+	var terms []string
+	for _, word := range strings.Fields(stripControl(text)) {
+		if !strings.ContainsFunc(word, isWordRune) || len([]rune(word)) < f.MinLength {
+			continue
+		}
+		term := fmt.Sprintf("%s~%d", EscapeTerm(strings.ToLower(word)), FuzzyDistance(word))
+
+		alternatives := make([]string, len(f.Fields))
+		for i, field := range f.Fields {
+			alternatives[i] = field + ":" + term
+		}
+		terms = append(terms, "+("+strings.Join(alternatives, " ")+")")
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("{!lucene}(%s)^%s", strings.Join(terms, " "),
+		strconv.FormatFloat(f.Boost, 'f', -1, 64)), nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
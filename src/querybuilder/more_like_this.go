@@ -0,0 +1,33 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MoreLikeThis busca documentos similares a ID con el query parser mlt de Solr
+type MoreLikeThis struct {
+	ID     string
+	Fields []string
+	// Frecuencia mínima del término en el documento y en el índice
+	MinTermFreq int
+	MinDocFreq  int
+}
+
+// render arma los local params de mlt. El id viaja en el parámetro mlt_id
+// para que no forme parte de la sintaxis de la query.
+func (m MoreLikeThis) render() (string, error) {
+	if m.ID == "" {
+		return "", fmt.Errorf("more like this requiere un id")
+	}
+	if len(m.Fields) == 0 {
+		return "", fmt.Errorf("more like this requiere al menos un campo")
+	}
+	for _, field := range m.Fields {
+		if err := validateField(field); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("{!mlt qf=%s mintf=%d mindf=%d v=$mlt_id}",
+		strings.Join(m.Fields, ","), m.MinTermFreq, m.MinDocFreq), nil
+}
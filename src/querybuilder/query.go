@@ -0,0 +1,134 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/vanng822/go-solr/solr"
+)
+
+// Edismax configura el parser edismax para el texto de usuario
+type Edismax struct {
+	QueryFields  string
+	PhraseFields string
+	MinimumMatch string
+}
+
+// Query describe una búsqueda de forma tipada. Todo valor proveniente del
+// usuario se escapa al construir los parámetros de Solr.
+type Query struct {
+	// Texto libre del usuario; vacío devuelve todos los documentos
+	Text    string
+	Edismax Edismax
+	// Expansión difusa opcional del texto; nil la desactiva
+	Fuzzy *Fuzzy
+	// Búsqueda de documentos similares; reemplaza al texto si está presente
+	MoreLikeThis *MoreLikeThis
+	Filters      []Filter
+	Sort         []Sort
+	Fields       []string
+	Start        int
+	Rows         int
+	// cursorMark de Solr; requiere que Sort incluya la clave única como desempate
+	CursorMark string
+}
+
+// Build construye la query de Solr con q, fq, sort y fl
+func (q Query) Build() (*solr.Query, error) {
+	solrQuery := solr.NewQuery()
+
+	if q.Edismax.QueryFields != "" {
+		solrQuery.QueryFields(q.Edismax.QueryFields)
+	}
+	if q.Edismax.PhraseFields != "" {
+		solrQuery.SetParam("pf", q.Edismax.PhraseFields)
+	}
+	if q.Edismax.MinimumMatch != "" {
+		solrQuery.SetParam("mm", q.Edismax.MinimumMatch)
+	}
+	// El usuario no puede consultar campos arbitrarios ni usar operadores en minúscula
+	solrQuery.SetParam("uf", "-*")
+	solrQuery.SetParam("lowercaseOperators", "false")
+
+	text := EscapeText(q.Text)
+	fuzzyClause := ""
+	if q.Fuzzy != nil && text != "" {
+		clause, err := q.Fuzzy.clause(q.Text)
+		if err != nil {
+			return nil, err
+		}
+		fuzzyClause = clause
+	}
+
+	switch {
+	case q.MoreLikeThis != nil:
+		mlt, err := q.MoreLikeThis.render()
+		if err != nil {
+			return nil, err
+		}
+		// Solr solo interpreta local params en q con defType lucene o func
+		solrQuery.DefType("lucene")
+		solrQuery.Q(mlt)
+		solrQuery.SetParam("mlt_id", q.MoreLikeThis.ID)
+	case fuzzyClause != "":
+		// El bool externo requiere defType lucene; edismax (con qf, pf, mm y uf
+		// del request) se aplica solo dentro de exact_q. El texto del usuario
+		// viaja en su propio parámetro y se referencia con $user_q, así nunca
+		// forma parte de la sintaxis de local params
+		solrQuery.DefType("lucene")
+		solrQuery.Q("{!bool should=$exact_q should=$fuzzy_q}")
+		solrQuery.SetParam("exact_q", "{!edismax v=$user_q}")
+		solrQuery.SetParam("user_q", text)
+		solrQuery.SetParam("fuzzy_q", fuzzyClause)
+	case text != "":
+		solrQuery.DefType("edismax")
+		solrQuery.Q(text)
+	default:
+		solrQuery.DefType("edismax")
+		solrQuery.SetParam("q.alt", "*:*")
+	}
+
+	for _, filter := range q.Filters {
+		fq, err := filter.render()
+		if err != nil {
+			return nil, err
+		}
+		solrQuery.FilterQuery(fq)
+	}
+
+	if len(q.Sort) > 0 {
+		clauses := make([]string, 0, len(q.Sort))
+		for _, sort := range q.Sort {
+			clause, err := sort.render()
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, clause)
+		}
+		solrQuery.Sort(strings.Join(clauses, ","))
+	}
+
+	if len(q.Fields) > 0 {
+		for _, field := range q.Fields {
+			if field == "score" {
+				continue
+			}
+			if err := validateField(field); err != nil {
+				return nil, err
+			}
+		}
+		solrQuery.FieldList(strings.Join(q.Fields, ","))
+	}
+
+	if q.CursorMark != "" {
+		// Solr no admite start junto con cursorMark
+		solrQuery.SetParam("cursorMark", q.CursorMark)
+		solrQuery.Start(0)
+	} else {
+		solrQuery.Start(q.Start)
+	}
+	if q.Rows > 0 {
+		solrQuery.Rows(q.Rows)
+	}
+
+	return solrQuery, nil
+}
@@ -7,10 +7,24 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, searchController *controllers.SearchController) {
+func SetupRoutes(router *gin.Engine, searchController *controllers.SearchController, adminController *controllers.AdminController, adminAuth gin.HandlerFunc) {
 	searchRoutes := router.Group("/search")
 	{
 		searchRoutes.GET("/", searchController.SearchCourses)
+		searchRoutes.GET("/suggest", searchController.SuggestCourses)
+		searchRoutes.GET("/export", searchController.ExportCourses)
+		searchRoutes.GET("/courses/:id", searchController.GetCourse)
+		searchRoutes.GET("/courses/:id/similar", searchController.SimilarCourses)
+	}
+
+	if adminAuth != nil {
+		adminRoutes := router.Group("/admin", adminAuth)
+		adminRoutes.GET("/schema", adminController.SchemaStatus)
+		adminRoutes.POST("/schema/migrate", adminController.MigrateSchema)
+		adminRoutes.GET("/dlq", adminController.ListDeadLetters)
+		adminRoutes.POST("/dlq/replay", adminController.ReplayAllDeadLetters)
+		adminRoutes.POST("/dlq/:id/replay", adminController.ReplayDeadLetter)
+		adminRoutes.GET("/consumer/stats", adminController.ConsumerStats)
 	}
 
 	router.NoRoute(func(c *gin.Context) {
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"search-courses-api/src/errors"
+	"search-courses-api/src/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// DecodeCourseEvent interpreta el cuerpo de un mensaje de RabbitMQ. Acepta el
+// sobre JSON versionado y el formato legacy con solo el id del curso, como
+// texto plano o como string JSON.
+func DecodeCourseEvent(body []byte) (*models.CourseEvent, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, errors.NewInvalidCourseEventError("mensaje vacío")
+	}
+
+	if body[0] != '{' {
+		courseID := string(body)
+		if body[0] == '"' {
+			if err := json.Unmarshal(body, &courseID); err != nil {
+				return nil, errors.NewInvalidCourseEventError("id de curso legacy mal formado")
+			}
+		}
+		if _, err := primitive.ObjectIDFromHex(courseID); err != nil {
+			return nil, errors.NewInvalidCourseEventError("el id de curso legacy no es un ObjectID")
+		}
+		return &models.CourseEvent{
+			Type:          models.CourseUpdated,
+			CourseID:      courseID,
+			SchemaVersion: models.CourseEventSchemaVersion,
+			Legacy:        true,
+		}, nil
+	}
+
+	var event models.CourseEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, errors.NewInvalidCourseEventError(fmt.Sprintf("JSON inválido: %v", err))
+	}
+	// Los productores anteriores al versionado no envían schema_version
+	if event.SchemaVersion == 0 {
+		event.SchemaVersion = models.CourseEventSchemaVersion
+	}
+	if event.SchemaVersion != models.CourseEventSchemaVersion {
+		return nil, errors.NewInvalidCourseEventError(fmt.Sprintf("schema_version %d no soportada", event.SchemaVersion))
+	}
+	if _, err := primitive.ObjectIDFromHex(event.CourseID); err != nil {
+		return nil, errors.NewInvalidCourseEventError("course_id no es un ObjectID")
+	}
+	switch event.Type {
+	case models.CourseCreated, models.CourseUpdated, models.CourseDeleted, models.CourseRatingChanged:
+	default:
+		return nil, errors.NewInvalidCourseEventError(fmt.Sprintf("tipo de evento %q desconocido", event.Type))
+	}
+	if event.Type == models.CourseRatingChanged && event.RatingAvg != nil &&
+		(*event.RatingAvg < 0 || *event.RatingAvg > 5) {
+		return nil, errors.NewInvalidCourseEventError("ratingavg fuera de rango")
+	}
+
+	return &event, nil
+}
+
+// HandleCourseMessage decodifica un mensaje de RabbitMQ y lo despacha según su tipo
+func (s *SearchService) HandleCourseMessage(body []byte) error {
+	event, err := DecodeCourseEvent(body)
+	if err != nil {
+		return err
+	}
+	return s.HandleCourseEvent(*event)
+}
+
+// HandleCourseEvent despacha el evento al handler correspondiente a su tipo
+func (s *SearchService) HandleCourseEvent(event models.CourseEvent) error {
+	s.logger.Info("[SEARCH-API] Evento de curso recibido",
+		zap.String("type", event.Type),
+		zap.String("course_id", event.CourseID),
+		zap.Int64("source_version", event.SourceVersion),
+		zap.Time("occurred_at", event.OccurredAt),
+		zap.Bool("legacy", event.Legacy))
+
+	switch event.Type {
+	case models.CourseCreated:
+		return s.handleCourseCreated(event)
+	case models.CourseUpdated:
+		return s.handleCourseUpdated(event)
+	case models.CourseDeleted:
+		return s.handleCourseDeleted(event)
+	case models.CourseRatingChanged:
+		return s.handleCourseRatingChanged(event)
+	}
+	return errors.NewInvalidCourseEventError(fmt.Sprintf("tipo de evento %q desconocido", event.Type))
+}
+
+func (s *SearchService) handleCourseCreated(event models.CourseEvent) error {
+	return s.UpdateCourseInSolr(event.CourseID)
+}
+
+func (s *SearchService) handleCourseUpdated(event models.CourseEvent) error {
+	return s.UpdateCourseInSolr(event.CourseID)
+}
+
+func (s *SearchService) handleCourseDeleted(event models.CourseEvent) error {
+	return s.DeleteCourseFromSolr(event.CourseID)
+}
+
+// handleCourseRatingChanged vuelve a indexar el curso desde courses-api. No se
+// aplica el ratingavg del evento: un reintento puede llegar después de un
+// cambio más reciente y sobrescribir la calificación vigente.
+func (s *SearchService) handleCourseRatingChanged(event models.CourseEvent) error {
+	return s.UpdateCourseInSolr(event.CourseID)
+}
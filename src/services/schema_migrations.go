@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+
+	"search-courses-api/src/config/schema"
+	"search-courses-api/src/models"
+
+	"go.uber.org/zap"
+)
+
+// SchemaStatus devuelve la versión de esquema aplicada y los pasos pendientes
+func (s *SearchService) SchemaStatus() (*models.SchemaMigrationStatus, error) {
+	if !s.solrClient.IsConnected() {
+		return nil, fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
+	}
+
+	migrations, err := schema.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.solrClient.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SchemaMigrationStatus{
+		CurrentVersion: current,
+		LatestVersion:  len(migrations),
+		Pending:        pendingSteps(migrations, current),
+	}, nil
+}
+
+// MigrateSchema aplica el esquema declarativo y luego los pasos pendientes en
+// orden, registrando la versión después de cada uno. Si el bootstrap reemplazó
+// campos existentes o algún paso aplicado requiere reindexar, al final se
+// recargan todos los cursos una única vez.
+func (s *SearchService) MigrateSchema() (*models.SchemaMigrationResult, error) {
+	s.migrateMu.Lock()
+	defer s.migrateMu.Unlock()
+
+	if !s.solrClient.IsConnected() {
+		return nil, fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
+	}
+
+	migrations, err := schema.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	// Si el bootstrap reemplazó campos existentes los cursos deben recargarse
+	reindex, err := s.solrClient.EnsureSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.solrClient.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if current > len(migrations) {
+		return nil, fmt.Errorf("el core tiene la versión de esquema %d, más nueva que la última migración conocida (%d)", current, len(migrations))
+	}
+
+	result := &models.SchemaMigrationResult{FromVersion: current, ToVersion: current}
+	for _, migration := range migrations[current:] {
+		s.logger.Info("[SEARCH-API] Aplicando migración de esquema",
+			zap.Int("version", migration.Version),
+			zap.String("migration", migration.Name),
+			zap.String("description", migration.Description))
+
+		if migration.Purge {
+			s.logger.Warn("[SEARCH-API] La migración borra los documentos indexados antes de aplicarse",
+				zap.String("migration", migration.Name))
+			if err := s.solrClient.PurgeDocuments(); err != nil {
+				return result, err
+			}
+		}
+		if err := s.solrClient.ApplySchemaMigration(migration); err != nil {
+			s.logger.Error("[SEARCH-API] Error al aplicar migración de esquema",
+				zap.String("migration", migration.Name),
+				zap.Error(err))
+			return result, err
+		}
+		if err := s.solrClient.SetSchemaVersion(migration.Version); err != nil {
+			return result, err
+		}
+
+		result.ToVersion = migration.Version
+		result.Applied = append(result.Applied, toMigrationStep(migration))
+		reindex = reindex || migration.Reindex
+	}
+
+	if reindex {
+		s.logger.Info("[SEARCH-API] Los cambios de esquema aplicados requieren reindexar los cursos")
+		if err := s.LoadAllCoursesIntoSolr(); err != nil {
+			return result, err
+		}
+		result.Reindexed = true
+	}
+
+	s.logger.Info("[SEARCH-API] Migración de esquema finalizada",
+		zap.Int("from_version", result.FromVersion),
+		zap.Int("to_version", result.ToVersion),
+		zap.Bool("reindexed", result.Reindexed))
+	return result, nil
+}
+
+func pendingSteps(migrations []schema.Migration, current int) []models.SchemaMigrationStep {
+	steps := []models.SchemaMigrationStep{}
+	for _, migration := range migrations {
+		if migration.Version > current {
+			steps = append(steps, toMigrationStep(migration))
+		}
+	}
+	return steps
+}
+
+func toMigrationStep(migration schema.Migration) models.SchemaMigrationStep {
+	return models.SchemaMigrationStep{
+		Version:     migration.Version,
+		Name:        migration.Name,
+		Description: migration.Description,
+		Reindex:     migration.Reindex,
+		Purge:       migration.Purge,
+	}
+}
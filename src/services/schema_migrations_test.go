@@ -0,0 +1,175 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"search-courses-api/src/clients"
+	"search-courses-api/src/config/schema"
+	"search-courses-api/src/config/search"
+
+	"go.uber.org/zap"
+)
+
+// fakeSolr guarda los documentos en memoria y responde el Schema API con el
+// esquema declarativo, salvo los campos indicados en liveFields
+type fakeSolr struct {
+	mu         sync.Mutex
+	docs       map[string]map[string]interface{}
+	liveFields map[string]map[string]interface{}
+	purges     int
+}
+
+func (f *fakeSolr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	ok := map[string]interface{}{"status": 0}
+	switch {
+	case r.URL.Path == "/solr/courses/schema" && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"responseHeader": ok, "schema": f.schema()})
+	case r.URL.Path == "/solr/courses/schema":
+		json.NewEncoder(w).Encode(map[string]interface{}{"responseHeader": ok})
+	case r.URL.Path == "/solr/courses/select":
+		docs := []interface{}{}
+		for _, fq := range r.URL.Query()["fq"] {
+			if id := strings.TrimSuffix(strings.TrimPrefix(fq, "id:("), ")"); id != fq {
+				if doc, found := f.docs[id]; found {
+					docs = append(docs, doc)
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"responseHeader": ok,
+			"response":       map[string]interface{}{"numFound": len(docs), "start": 0, "docs": docs},
+		})
+	case r.URL.Path == "/solr/courses/update/":
+		var update struct {
+			Add    []map[string]interface{} `json:"add"`
+			Delete map[string]interface{}   `json:"delete"`
+		}
+		json.NewDecoder(r.Body).Decode(&update)
+		for _, doc := range update.Add {
+			f.docs[doc["id"].(string)] = doc
+		}
+		if update.Delete["query"] == "*:*" {
+			f.docs = map[string]map[string]interface{}{}
+			f.purges++
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"responseHeader": ok})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeSolr) schema() map[string]interface{} {
+	definition, _ := schema.LoadDefinition()
+	fields := []interface{}{}
+	for _, field := range definition.Fields {
+		if live, ok := f.liveFields[field["name"].(string)]; ok {
+			field = live
+		}
+		fields = append(fields, field)
+	}
+	copyFields := []interface{}{}
+	for _, copyField := range definition.CopyFields {
+		copyFields = append(copyFields, map[string]interface{}{"source": copyField.Source, "dest": copyField.Dest})
+	}
+	return map[string]interface{}{
+		"fieldTypes":    definition.FieldTypes,
+		"fields":        fields,
+		"dynamicFields": []interface{}{},
+		"copyFields":    copyFields,
+	}
+}
+
+// newTestSearchService arma el servicio con un SolrClient real apuntando a
+// solr y una courses-api sin cursos
+func newTestSearchService(t *testing.T, solr http.Handler) *SearchService {
+	t.Helper()
+
+	solrServer := httptest.NewServer(solr)
+	t.Cleanup(solrServer.Close)
+	coursesAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(coursesAPI.Close)
+
+	solrURL, err := url.Parse(solrServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLR_HOST", solrURL.Hostname())
+	t.Setenv("SOLR_PORT", solrURL.Port())
+	t.Setenv("SOLR_CORE", "courses")
+
+	// NewSolrClient carga .env del directorio actual
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/.env", nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	config := &search.Config{IndexBatchSize: 100}
+	logger := zap.NewNop()
+	solrClient := clients.NewSolrClient(logger, config)
+	solrClient.WaitForConnection()
+	return NewSearchService(solrClient, logger, coursesAPI.URL, config)
+}
+
+func TestMigrateSchemaKeepsVersionAcrossBootstrapPurge(t *testing.T) {
+	migrations, err := schema.LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := len(migrations)
+
+	// ratingavg quedó multivaluado por el esquema data-driven: el bootstrap
+	// debe borrar los documentos antes de reemplazarlo
+	solr := &fakeSolr{
+		docs: map[string]map[string]interface{}{
+			"_schema_metadata":         {"id": "_schema_metadata", "schema_version": latest},
+			"64b7f1a2c3d4e5f607182930": {"id": "64b7f1a2c3d4e5f607182930", "ratingavg": []interface{}{4.5}},
+		},
+		liveFields: map[string]map[string]interface{}{
+			"ratingavg": {"name": "ratingavg", "type": "pdoubles", "indexed": true, "stored": true, "multiValued": true},
+		},
+	}
+	service := newTestSearchService(t, solr)
+
+	result, err := service.MigrateSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.FromVersion != latest || len(result.Applied) != 0 {
+		t.Fatalf("se aplicaron migraciones ya registradas: desde %d, aplicadas %d", result.FromVersion, len(result.Applied))
+	}
+	if solr.purges != 1 {
+		t.Fatalf("purgas = %d, se esperaba solo la del bootstrap", solr.purges)
+	}
+	if !result.Reindexed {
+		t.Fatal("el bootstrap reemplazó un campo y no se reindexó")
+	}
+	version, err := service.solrClient.SchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != latest {
+		t.Fatalf("versión de esquema = %d después de la purga, se esperaba %d", version, latest)
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"io"
 	"net/http"
 	"search-courses-api/src/clients"
+	"search-courses-api/src/config/search"
+	"search-courses-api/src/errors"
 	"search-courses-api/src/models"
+	"strings"
+	"sync"
 
 	"go.uber.org/zap"
 )
@@ -15,13 +19,17 @@ type SearchService struct {
 	solrClient *clients.SolrClient
 	logger     *zap.Logger
 	coursesAPI string
+	config     *search.Config
+	// Evita ejecutar dos migraciones de esquema a la vez
+	migrateMu sync.Mutex
 }
 
-func NewSearchService(solrClient *clients.SolrClient, logger *zap.Logger, coursesAPI string) *SearchService {
+func NewSearchService(solrClient *clients.SolrClient, logger *zap.Logger, coursesAPI string, config *search.Config) *SearchService {
 	return &SearchService{
 		solrClient: solrClient,
 		logger:     logger,
 		coursesAPI: coursesAPI,
+		config:     config,
 	}
 }
 
@@ -34,6 +42,12 @@ func (s *SearchService) UpdateCourseInSolr(courseID string) error {
 	}
 
 	courseData, err := s.getCourseByID(courseID)
+	if err == errors.ErrCourseNotFound {
+		// courses-api confirma que el curso ya no existe: se quita del índice
+		s.logger.Info("[SEARCH-API] El curso no existe en courses-api, se elimina de Solr",
+			zap.String("course_id", courseID))
+		return s.DeleteCourseFromSolr(courseID)
+	}
 	if err != nil {
 		s.logger.Error("[SEARCH-API] Error al obtener datos del curso",
 			zap.String("course_id", courseID),
@@ -45,19 +59,42 @@ func (s *SearchService) UpdateCourseInSolr(courseID string) error {
 		zap.String("course_id", courseID),
 		zap.String("course_name", courseData.CourseName))
 
-	err = s.solrClient.AddCourse(courseData)
+	report, err := s.solrClient.AddCourse(courseData)
 	if err != nil {
 		s.logger.Error("Error al actualizar curso en Solr",
 			zap.String("course_id", courseID),
 			zap.Error(err))
 		return err
 	}
+	if len(report.InvalidInitDates) > 0 {
+		s.logger.Warn("Curso indexado sin fecha de inicio por formato no reconocido",
+			zap.String("course_id", courseID),
+			zap.String("init_date", courseData.CourseInitDate))
+	}
 
 	s.logger.Info("Curso actualizado exitosamente en Solr",
 		zap.String("course_id", courseID))
 	return nil
 }
 
+// DeleteCourseFromSolr quita un curso del índice; borrar uno inexistente no es un error
+func (s *SearchService) DeleteCourseFromSolr(courseID string) error {
+	if !s.solrClient.IsConnected() {
+		return fmt.Errorf("Conexión a Solr no establecida")
+	}
+
+	if err := s.solrClient.DeleteCourse(courseID); err != nil {
+		s.logger.Error("Error al eliminar curso de Solr",
+			zap.String("course_id", courseID),
+			zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Curso eliminado exitosamente de Solr",
+		zap.String("course_id", courseID))
+	return nil
+}
+
 func (s *SearchService) LoadAllCoursesIntoSolr() error {
 	s.logger.Info("Cargando todos los cursos en Solr")
 
@@ -67,32 +104,54 @@ func (s *SearchService) LoadAllCoursesIntoSolr() error {
 		return err
 	}
 
-	for _, course := range courses {
-		err = s.solrClient.AddCourse(&course)
-		if err != nil {
-			s.logger.Error("Error al agregar curso a Solr", zap.String("courseID", course.ID.Hex()), zap.Error(err))
-		}
+	report, err := s.solrClient.AddCourses(courses)
+	if err != nil {
+		s.logger.Error("Error al cargar los cursos en Solr", zap.Error(err))
+		return err
 	}
 
-	s.logger.Info("Todos los cursos cargados en Solr")
+	if len(report.InvalidInitDates) > 0 {
+		s.logger.Warn("Cursos indexados sin fecha de inicio por formato no reconocido",
+			zap.Strings("course_ids", report.InvalidInitDates))
+	}
+
+	s.logger.Info("Carga de cursos en Solr finalizada",
+		zap.Int("total", report.Total),
+		zap.Int("indexados", report.Indexed),
+		zap.Int("lotes", report.Batches),
+		zap.Int("lotes_fallidos", len(report.Failures)))
+
+	if len(report.Failures) > 0 {
+		return fmt.Errorf("%d de %d lotes de cursos no pudieron indexarse", len(report.Failures), report.Batches)
+	}
 	return nil
 }
 
 // Métodos auxiliares para obtener datos desde courses-api
 
+// getCourseByID obtiene el curso de courses-api. Un 404 devuelve
+// ErrCourseNotFound; los demás errores, incluidos los transitorios, se
+// devuelven sin reintentar: los reintenta la cola de RabbitMQ con su backoff,
+// sin retener el worker del curso.
 func (s *SearchService) getCourseByID(courseID string) (*models.SearchCourseModel, error) {
 	url := fmt.Sprintf("%s/%s", s.coursesAPI, courseID)
 	resp, err := http.Get(url)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error al realizar la solicitud GET: %v", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.ErrCourseNotFound
+	case resp.StatusCode != http.StatusOK:
 		return nil, fmt.Errorf("error al obtener el curso, código de estado: %d", resp.StatusCode)
 	}
 
-	body, _ := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer el cuerpo de la respuesta: %v", err)
+	}
 	var course models.SearchCourseModel
 	if err := json.Unmarshal(body, &course); err != nil {
 		return nil, err
@@ -126,24 +185,190 @@ func (s *SearchService) getAllCourses() ([]models.SearchCourseModel, error) {
 	return courses, nil
 }
 
-func (s *SearchService) SearchCourses(query string) ([]models.SearchCourseModel, error) {
+func (s *SearchService) SearchCourses(params models.SearchCoursesQuery) (*models.SearchCoursesResult, error) {
 	if !s.solrClient.IsConnected() {
 		return nil, fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
 	}
 
 	s.logger.Info("Iniciando búsqueda de cursos",
-		zap.String("query", query))
+		zap.String("query", params.Query),
+		zap.Int("page", params.Page),
+		zap.Int("page_size", params.PageSize))
 
-	courses, err := s.solrClient.SearchCourses(query)
+	result, err := s.solrClient.SearchCourses(params)
 	if err != nil {
 		s.logger.Error("Error al buscar cursos",
-			zap.String("query", query),
+			zap.String("query", params.Query),
 			zap.Error(err))
 		return nil, err
 	}
 
+	if params.Query != "" && result.Total <= s.config.SpellcheckThreshold {
+		result = s.applySpellcheck(params, result)
+	}
+
 	s.logger.Info("Búsqueda completada exitosamente",
-		zap.String("query", query),
+		zap.String("query", params.Query),
+		zap.Int("resultados", len(result.Courses)),
+		zap.Int("total", result.Total))
+	return result, nil
+}
+
+func (s *SearchService) GetCourse(courseID string) (*models.SearchCourseModel, error) {
+	if !s.solrClient.IsConnected() {
+		return nil, fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
+	}
+
+	course, err := s.solrClient.GetCourseByID(courseID)
+	if err != nil {
+		s.logger.Error("Error al obtener el curso desde Solr",
+			zap.String("course_id", courseID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	if course == nil {
+		return nil, errors.ErrCourseNotFound
+	}
+
+	return course, nil
+}
+
+// ExportCourses recorre el resultado completo de la búsqueda con cursorMark y
+// entrega los cursos a handle de a un bloque por vez, sin acumularlos en memoria
+func (s *SearchService) ExportCourses(params models.SearchCoursesQuery, handle func([]models.SearchCourseModel) error) error {
+	if !s.solrClient.IsConnected() {
+		return fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
+	}
+
+	params.PageSize = s.config.ExportChunkSize
+	params.Cursor = "*"
+	params.IncludeFacets = false
+	params.Highlight = false
+
+	exported := 0
+	for {
+		result, err := s.solrClient.SearchCourses(params)
+		if err != nil {
+			s.logger.Error("Error al exportar cursos",
+				zap.String("query", params.Query),
+				zap.Int("exportados", exported),
+				zap.Error(err))
+			return err
+		}
+
+		if len(result.Courses) > 0 {
+			if err := handle(result.Courses); err != nil {
+				return err
+			}
+			exported += len(result.Courses)
+		}
+
+		if result.NextCursor == "" {
+			break
+		}
+		params.Cursor = result.NextCursor
+	}
+
+	s.logger.Info("Exportación de cursos completada",
+		zap.String("query", params.Query),
+		zap.Int("exportados", exported))
+	return nil
+}
+
+// applySpellcheck agrega la sugerencia "did you mean" y, si no hubo resultados y
+// la autocorrección está activa, repite la búsqueda con la query corregida.
+// Los errores del spellcheck no hacen fallar la búsqueda original.
+func (s *SearchService) applySpellcheck(params models.SearchCoursesQuery, result *models.SearchCoursesResult) *models.SearchCoursesResult {
+	collation, err := s.solrClient.SpellCheck(params.Query)
+	if err != nil {
+		s.logger.Warn("No se pudo obtener la corrección ortográfica",
+			zap.String("query", params.Query),
+			zap.Error(err))
+		return result
+	}
+	if collation == "" || strings.EqualFold(collation, params.Query) {
+		return result
+	}
+
+	result.DidYouMean = collation
+	// En modo cursor no se cambia la query: las páginas siguientes la repetirían sin corregir
+	if result.Total > 0 || !params.AutoCorrect || params.Cursor != "" {
+		return result
+	}
+
+	corrected := params
+	corrected.Query = collation
+	correctedResult, err := s.solrClient.SearchCourses(corrected)
+	if err != nil {
+		s.logger.Warn("Error al repetir la búsqueda con la query corregida",
+			zap.String("query", params.Query),
+			zap.String("corrected_query", collation),
+			zap.Error(err))
+		return result
+	}
+	if correctedResult.Total == 0 {
+		return result
+	}
+
+	s.logger.Info("Búsqueda repetida con la query corregida",
+		zap.String("query", params.Query),
+		zap.String("corrected_query", collation),
+		zap.Int("total", correctedResult.Total))
+
+	correctedResult.DidYouMean = collation
+	correctedResult.AutoCorrected = true
+	return correctedResult
+}
+
+// SuggestCourses devuelve sugerencias de autocompletado. Un limit de 0 usa el valor configurado.
+func (s *SearchService) SuggestCourses(prefix string, limit int) ([]models.CourseSuggestion, error) {
+	if !s.solrClient.IsConnected() {
+		return nil, fmt.Errorf("Servicio de búsqueda no disponible temporalmente")
+	}
+
+	if limit == 0 {
+		limit = s.config.SuggestLimit
+	}
+	if limit < 1 || limit > s.config.SuggestMaxLimit {
+		return nil, errors.ErrInvalidLimit
+	}
+
+	suggestions, err := s.solrClient.SuggestCourses(prefix, limit)
+	if err != nil {
+		s.logger.Error("Error al obtener sugerencias",
+			zap.String("prefix", prefix),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// SimilarCourses devuelve cursos parecidos al indicado. Un limit de 0 usa el valor configurado.
+func (s *SearchService) SimilarCourses(courseID string, filters models.SearchFilters, limit int) ([]models.SearchCourseModel, error) {
+	if limit == 0 {
+		limit = s.config.SimilarLimit
+	}
+	if limit < 1 || limit > s.config.SimilarMaxLimit {
+		return nil, errors.ErrInvalidLimit
+	}
+
+	// Verificar que el curso de origen esté indexado
+	if _, err := s.GetCourse(courseID); err != nil {
+		return nil, err
+	}
+
+	courses, err := s.solrClient.SimilarCourses(courseID, filters, limit)
+	if err != nil {
+		s.logger.Error("Error al buscar cursos similares",
+			zap.String("course_id", courseID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Búsqueda de cursos similares completada",
+		zap.String("course_id", courseID),
 		zap.Int("resultados", len(courses)))
 	return courses, nil
 }